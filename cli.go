@@ -0,0 +1,928 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+// Exit codes for the headless commands
+const (
+	exitOK         = 0
+	exitError      = 1
+	exitUsage      = 2
+	exitNotFound   = 3
+	exitNotRunning = 4
+	exitConfig     = 5
+)
+
+var errProjectNotFound = errors.New("project not found")
+
+const cliUsage = `Usage: project-launcher [command] [flags]
+
+With no command the interactive launcher starts.
+
+Commands:
+  list                 List configured projects
+  status [name]        Show which projects are running
+  run <name>           Launch a project in the background (--wait to stay attached,
+                       --profile to pick a launch profile)
+  stop <name>          Stop a running project
+  open <name>          Open a project's link in the browser
+  path <name>          Print a project's directory, for cd "$(project-launcher path api)"
+                       (--profile to use a launch profile's directory)
+  add                  Add a project
+  edit <name>          Change fields of a project
+  remove <name>        Remove a project
+  group list           List launch groups
+  group start <name>   Launch a group's projects in dependency order
+  group stop <name>    Stop a group's projects, dependents first
+
+Every command accepts --json for machine-readable output.
+`
+
+// cli carries what every subcommand needs
+type cli struct {
+	configFile  string
+	store       *projectStore
+	settings    Settings
+	settingsErr error // Why settings.json could not be loaded
+	json        bool
+	stdout      io.Writer
+	stderr      io.Writer
+}
+
+func runCLI(args []string, configFile string) int {
+	settings, settingsErr := loadSettings(settingsFileFor(configFile))
+	c := &cli{
+		configFile:  configFile,
+		store:       newProjectStore(configFile, settings.historyCount()),
+		settings:    settings,
+		settingsErr: settingsErr,
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+	}
+
+	name, args := args[0], args[1:]
+	if settingsErr != nil && name != "group" {
+		fmt.Fprintf(c.stderr, "project-launcher: settings not loaded, using defaults: %v\n", settingsErr)
+	}
+	switch name {
+	case "list", "ls":
+		return c.list(args)
+	case "status":
+		return c.status(args)
+	case "run":
+		return c.run(args)
+	case "stop":
+		return c.stop(args)
+	case "open":
+		return c.open(args)
+	case "path":
+		return c.path(args)
+	case "add":
+		return c.add(args)
+	case "edit":
+		return c.edit(args)
+	case "remove", "rm":
+		return c.remove(args)
+	case "group":
+		return c.group(args)
+	case supervisorCommand:
+		return c.supervise(args)
+	case "help", "-h", "--help":
+		fmt.Fprint(c.stdout, cliUsage)
+		return exitOK
+	default:
+		fmt.Fprintf(c.stderr, "unknown command %q\n\n%s", name, cliUsage)
+		return exitUsage
+	}
+}
+
+func (c *cli) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.BoolVar(&c.json, "json", false, "print JSON output")
+	return fs
+}
+
+// parseArgs parses flags that may appear before or after positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseNamed parses a command that takes exactly one project name
+func (c *cli) parseNamed(fs *flag.FlagSet, args []string) (string, bool) {
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return "", false
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(c.stderr, "usage: project-launcher %s <name>\n", fs.Name())
+		return "", false
+	}
+	return positional[0], true
+}
+
+func (c *cli) fail(code int, format string, args ...any) int {
+	fmt.Fprintf(c.stderr, "project-launcher: "+format+"\n", args...)
+	return code
+}
+
+func (c *cli) printJSON(v any) int {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return c.fail(exitError, "%v", err)
+	}
+	return exitOK
+}
+
+// findProject looks a project up by ID, or by name ignoring case
+func findProject(projects []Project, name string) (int, error) {
+	for i, project := range projects {
+		if project.ID == name {
+			return i, nil
+		}
+	}
+
+	found := -1
+	for i, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			if found != -1 {
+				return -1, fmt.Errorf("%q matches more than one project", name)
+			}
+			found = i
+		}
+	}
+	if found == -1 {
+		return -1, fmt.Errorf("%w: %q", errProjectNotFound, name)
+	}
+	return found, nil
+}
+
+// load reads the project list, reporting failures
+func (c *cli) load() ([]Project, int) {
+	projects, err := c.store.Load()
+	if err != nil {
+		return nil, c.fail(exitConfig, "cannot load config: %v", err)
+	}
+	return projects, exitOK
+}
+
+func (c *cli) save(projects []Project) int {
+	if err := c.store.Save(projects); err != nil {
+		return c.fail(exitConfig, "cannot save config: %v", err)
+	}
+	return exitOK
+}
+
+// lookup loads the projects and resolves name, reporting failures
+func (c *cli) lookup(name string) ([]Project, int, int) {
+	projects, failed := c.load()
+	if failed != exitOK {
+		return nil, -1, failed
+	}
+	index, err := findProject(projects, name)
+	if err != nil {
+		code := exitError
+		if errors.Is(err, errProjectNotFound) {
+			code = exitNotFound
+		}
+		return nil, -1, c.fail(code, "%v", err)
+	}
+	return projects, index, exitOK
+}
+
+func (c *cli) list(args []string) int {
+	fs := c.flagSet("list")
+	if _, err := parseArgs(fs, args); err != nil {
+		return exitUsage
+	}
+
+	projects, failed := c.load()
+	if failed != exitOK {
+		return failed
+	}
+	if c.json {
+		if projects == nil {
+			projects = []Project{}
+		}
+		return c.printJSON(projects)
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCATEGORY\tPATH\tCOMMAND")
+	for _, project := range projects {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", project.ID, project.Name, project.Category, project.Path, project.Command)
+	}
+	w.Flush()
+	return exitOK
+}
+
+type statusEntry struct {
+	Name          string     `json:"name"`
+	Running       bool       `json:"running"`
+	Profile       string     `json:"profile,omitempty"`
+	PID           int        `json:"pid,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	UptimeSeconds int64      `json:"uptime_seconds,omitempty"`
+	LogPath       string     `json:"log_path,omitempty"`
+}
+
+func projectStatus(project Project) statusEntry {
+	entry := statusEntry{Name: project.Name}
+	record, ok := readRunRecord(processKey(project))
+	if !ok || !record.alive() {
+		return entry
+	}
+	entry.Running = true
+	entry.PID = record.PID
+	entry.Profile = record.Profile
+	entry.StartedAt = &record.StartedAt
+	entry.UptimeSeconds = int64(time.Since(record.StartedAt).Seconds())
+	entry.LogPath = record.LogPath
+	return entry
+}
+
+// status prints every project's state, or one project's state with an exit
+// code saying whether it is running
+func (c *cli) status(args []string) int {
+	fs := c.flagSet("status")
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) > 1 {
+		return exitUsage
+	}
+
+	var entries []statusEntry
+	code := exitOK
+	if len(positional) == 1 {
+		projects, index, failed := c.lookup(positional[0])
+		if failed != exitOK {
+			return failed
+		}
+		entry := projectStatus(projects[index])
+		if !entry.Running {
+			code = exitNotRunning
+		}
+		entries = append(entries, entry)
+	} else {
+		projects, failed := c.load()
+		if failed != exitOK {
+			return failed
+		}
+		for _, project := range projects {
+			entries = append(entries, projectStatus(project))
+		}
+	}
+
+	if c.json {
+		if entries == nil {
+			entries = []statusEntry{}
+		}
+		if failed := c.printJSON(entries); failed != exitOK {
+			return failed
+		}
+		return code
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATUS\tPID\tUPTIME")
+	for _, entry := range entries {
+		if entry.Running {
+			fmt.Fprintf(w, "%s\trunning\t%d\t%s\n", entry.Name, entry.PID, formatUptime(time.Duration(entry.UptimeSeconds)*time.Second))
+		} else {
+			fmt.Fprintf(w, "%s\tstopped\t-\t-\n", entry.Name)
+		}
+	}
+	w.Flush()
+	return code
+}
+
+func (c *cli) run(args []string) int {
+	fs := c.flagSet("run")
+	wait := fs.Bool("wait", false, "stay attached, echo output and exit with the project's exit code")
+	profile := fs.String("profile", "", "launch profile to use instead of the default")
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	projects, index, failed := c.lookup(name)
+	if failed != exitOK {
+		return failed
+	}
+	project, err := projects[index].withProfile(*profile)
+	if err != nil {
+		return c.fail(exitNotFound, "%v", err)
+	}
+
+	if record, ok := readRunRecord(processKey(project)); ok && record.alive() {
+		return c.fail(exitError, "%s is already running (pid %d)", project.Name, record.PID)
+	}
+
+	if !*wait {
+		pid, logPath, err := c.startDetached(project)
+		if err != nil {
+			return c.fail(exitError, "%v", err)
+		}
+		if c.json {
+			return c.printJSON(map[string]any{"name": project.Name, "pid": pid, "log_path": logPath})
+		}
+		fmt.Fprintf(c.stdout, "🚀 Launched %s (pid %d) → Log: %s\n", project.Name, pid, logPath)
+		return exitOK
+	}
+
+	// With --json, stdout is reserved for the result object
+	echoOut := c.stdout
+	if c.json {
+		echoOut = c.stderr
+	}
+	cmd, logs, err := c.startLogged(project, echoOut, c.stderr)
+	if err != nil {
+		return c.fail(exitError, "%v", err)
+	}
+	pid := cmd.Process.Pid
+	exitCode := waitLogged(project, cmd, logs)
+	if c.json {
+		c.printJSON(map[string]any{"name": project.Name, "pid": pid, "exit_code": exitCode})
+	}
+	if exitCode < 0 {
+		return exitError
+	}
+	return exitCode
+}
+
+// startLogged launches project with its output tagged into its log, and
+// copied to echoOut and echoErr when they are set
+func (c *cli) startLogged(project Project, echoOut, echoErr io.Writer) (*exec.Cmd, *launchLog, error) {
+	cmd, _, err := buildLaunchCommand(project)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot launch %s: %w", project.Name, err)
+	}
+	logs, err := openLaunchLog(logPathFor(project, c.settings), c.settings.logMaxBytes(), c.settings.logRetention())
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open log: %w", err)
+	}
+	logs.writeLaunch(project.Command)
+	cmd.Stdout, cmd.Stderr = logs.streamWriter("stdout"), logs.streamWriter("stderr")
+	if echoOut != nil {
+		cmd.Stdout = io.MultiWriter(echoOut, cmd.Stdout)
+	}
+	if echoErr != nil {
+		cmd.Stderr = io.MultiWriter(echoErr, cmd.Stderr)
+	}
+
+	if err := cmd.Start(); err != nil {
+		logs.writeLine("launcher", fmt.Sprintf("=== Failed to start: %v ===", err))
+		logs.Close()
+		return nil, nil, fmt.Errorf("failed to launch %s: %w", project.Name, err)
+	}
+	writeRunRecord(project, cmd.Process.Pid, logs)
+	return cmd, logs, nil
+}
+
+// waitLogged waits for a command started by startLogged, records its exit in
+// the log and returns its exit code, -1 when it was killed by a signal
+func waitLogged(project Project, cmd *exec.Cmd, logs *launchLog) int {
+	key, pid := processKey(project), cmd.Process.Pid
+	msg := processExitedMsg{key: key, pid: pid}
+	msg.exitCode, msg.signaled = exitStatus(cmd.Wait())
+	logs.finish(msg)
+	removeRunRecord(key, pid)
+	return msg.exitCode
+}
+
+// supervisorStart is what the supervisor reports once the project runs
+type supervisorStart struct {
+	PID     int    `json:"pid,omitempty"`
+	LogPath string `json:"log_path,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// startDetached launches project in the background and leaves it running
+// after the CLI exits. A supervisor, this program started again in its own
+// session, stays behind to tag and rotate the project's output like the
+// interactive launcher does, and records its exit.
+func (c *cli) startDetached(project Project) (pid int, logPath string, err error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot start supervisor: %w", err)
+	}
+	supervisor := exec.Command(exe, supervisorCommand, project.ID, "--profile", project.profile)
+	supervisor.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	report, err := supervisor.StdoutPipe()
+	if err != nil {
+		return 0, "", err
+	}
+	if err := supervisor.Start(); err != nil {
+		return 0, "", fmt.Errorf("cannot start supervisor: %w", err)
+	}
+	defer supervisor.Process.Release()
+
+	// The supervisor closes its stdout once the project has started or failed
+	var started supervisorStart
+	if err := json.NewDecoder(report).Decode(&started); err != nil {
+		return 0, "", fmt.Errorf("failed to launch %s: supervisor exited without reporting", project.Name)
+	}
+	if started.Error != "" {
+		return 0, "", errors.New(started.Error)
+	}
+	return started.PID, started.LogPath, nil
+}
+
+// supervisorCommand is the hidden subcommand startDetached runs
+const supervisorCommand = "__supervise"
+
+// supervise launches a project for startDetached, reports its pid on stdout
+// and then waits for it, writing its output to the log
+func (c *cli) supervise(args []string) int {
+	fs := c.flagSet(supervisorCommand)
+	profile := fs.String("profile", "", "launch profile")
+	report := func(started supervisorStart) {
+		json.NewEncoder(c.stdout).Encode(started)
+		if closer, ok := c.stdout.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+
+	id, ok := c.parseNamed(fs, args)
+	if !ok {
+		report(supervisorStart{Error: "bad supervisor arguments"})
+		return exitUsage
+	}
+	projects, err := c.store.Load()
+	if err != nil {
+		report(supervisorStart{Error: fmt.Sprintf("cannot load config: %v", err)})
+		return exitConfig
+	}
+	index, err := findProject(projects, id)
+	if err != nil {
+		report(supervisorStart{Error: err.Error()})
+		return exitNotFound
+	}
+	project, err := projects[index].withProfile(*profile)
+	if err != nil {
+		report(supervisorStart{Error: err.Error()})
+		return exitNotFound
+	}
+
+	cmd, logs, err := c.startLogged(project, nil, nil)
+	if err != nil {
+		report(supervisorStart{Error: err.Error()})
+		return exitError
+	}
+	report(supervisorStart{PID: cmd.Process.Pid, LogPath: logs.path})
+	waitLogged(project, cmd, logs)
+	return exitOK
+}
+
+func (c *cli) stop(args []string) int {
+	fs := c.flagSet("stop")
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	projects, index, failed := c.lookup(name)
+	if failed != exitOK {
+		return failed
+	}
+	project := projects[index]
+
+	record, ok := readRunRecord(processKey(project))
+	if !ok || !record.alive() {
+		return c.fail(exitNotRunning, "%s is not running", project.Name)
+	}
+	killed, err := c.stopRecord(project, record)
+	if err != nil {
+		return c.fail(exitError, "failed to stop %s: %v", project.Name, err)
+	}
+
+	if c.json {
+		return c.printJSON(map[string]any{"name": project.Name, "pid": record.PID, "killed": killed})
+	}
+	if killed {
+		fmt.Fprintf(c.stdout, "💀 Killed %s after %s grace period\n", project.Name, c.settings.stopGrace())
+	} else {
+		fmt.Fprintf(c.stdout, "⏹️ Stopped %s\n", project.Name)
+	}
+	return exitOK
+}
+
+// stopRecord sends SIGTERM to a running project and SIGKILL once the grace
+// period is over, reporting whether it had to be killed
+func (c *cli) stopRecord(project Project, record runRecord) (killed bool, err error) {
+	// Tells the launcher waiting for the process not to restart it
+	record.Stopping = true
+	saveRunRecord(processKey(project), record)
+	if err := record.signal(syscall.SIGTERM); err != nil {
+		return false, err
+	}
+	deadline := time.Now().Add(c.settings.stopGrace())
+	for record.alive() && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if record.alive() {
+		record.signal(syscall.SIGKILL)
+		killed = true
+	}
+	// The launcher that started it reads the record once it sees the exit
+	if !record.ownerAlive() {
+		removeRunRecord(processKey(project), record.PID)
+	}
+	return killed, nil
+}
+
+func (c *cli) open(args []string) int {
+	fs := c.flagSet("open")
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	projects, index, failed := c.lookup(name)
+	if failed != exitOK {
+		return failed
+	}
+	project := projects[index]
+
+	if project.Link == "" {
+		return c.fail(exitError, "%s has no link", project.Name)
+	}
+	if err := openLink(project.Link); err != nil {
+		return c.fail(exitError, "failed to open link: %v", err)
+	}
+	if c.json {
+		return c.printJSON(map[string]any{"name": project.Name, "link": project.Link})
+	}
+	fmt.Fprintf(c.stdout, "🌐 Opened %s\n", project.Link)
+	return exitOK
+}
+
+// path prints a project's directory so a shell function can cd into it
+func (c *cli) path(args []string) int {
+	fs := c.flagSet("path")
+	profile := fs.String("profile", "", "launch profile whose directory to print")
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	projects, index, failed := c.lookup(name)
+	if failed != exitOK {
+		return failed
+	}
+	project := projects[index]
+	if *profile != "" {
+		resolved, err := project.withProfile(*profile)
+		if err != nil {
+			return c.fail(exitNotFound, "%v", err)
+		}
+		project = resolved
+	}
+	if c.json {
+		return c.printJSON(map[string]any{"name": project.Name, "path": project.Path})
+	}
+	fmt.Fprintln(c.stdout, project.Path)
+	return exitOK
+}
+
+// projectFlags registers the editable project fields on fs
+func projectFlags(fs *flag.FlagSet, project *Project) {
+	fs.StringVar(&project.Name, "name", project.Name, "display name")
+	fs.StringVar(&project.Path, "path", project.Path, "project directory")
+	fs.StringVar(&project.Command, "command", project.Command, "launch command")
+	fs.StringVar(&project.Link, "link", project.Link, "URL opened with 'open'")
+	fs.StringVar(&project.Category, "category", project.Category, "category used for grouping")
+	fs.StringVar(&project.LogFile, "log-file", project.LogFile, "log file, relative to the project path")
+	fs.Func("platform", "where the command runs under WSL: windows, linux or auto", func(value string) error {
+		switch strings.ToLower(value) {
+		case platformAuto, platformWindows, platformLinux:
+			project.Platform = strings.ToLower(value)
+			return nil
+		}
+		return fmt.Errorf("must be windows, linux or auto")
+	})
+}
+
+// linuxProjectPath accepts Windows paths for a project directory, so paths
+// copied from Explorer (C:\src, \\wsl$\Ubuntu\home\...) work as they are
+func linuxProjectPath(path string) string {
+	if linuxPath, err := currentWSLPaths().toLinux(path); err == nil {
+		return linuxPath
+	}
+	return path
+}
+
+func (c *cli) add(args []string) int {
+	var project Project
+	fs := c.flagSet("add")
+	projectFlags(fs, &project)
+	positional, err := parseArgs(fs, args)
+	if err != nil || len(positional) > 0 {
+		return exitUsage
+	}
+
+	if project.Path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return c.fail(exitError, "%v", err)
+		}
+		project.Path = cwd
+	}
+	project.Path = linuxProjectPath(project.Path)
+	if abs, err := filepath.Abs(expandHome(project.Path)); err == nil {
+		project.Path = abs
+	}
+	if project.Name == "" {
+		project.Name = filepath.Base(project.Path)
+	}
+	if project.Command == "" {
+		if suggestions := detectCommands(project.Path); len(suggestions) > 0 {
+			project.Command = suggestions[0].Command
+		}
+	}
+	project.ID = newProjectID()
+
+	projects, failed := c.load()
+	if failed != exitOK {
+		return failed
+	}
+	projects = append(projects, project)
+	if failed := c.save(projects); failed != exitOK {
+		return failed
+	}
+	if c.json {
+		return c.printJSON(project)
+	}
+	fmt.Fprintf(c.stdout, "➕ Added %s\n", project.Name)
+	if project.Command != "" {
+		fmt.Fprintf(c.stdout, "   Command: %s\n", project.Command)
+	}
+	return exitOK
+}
+
+func (c *cli) edit(args []string) int {
+	var changes Project
+	fs := c.flagSet("edit")
+	projectFlags(fs, &changes)
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	projects, index, failed := c.lookup(name)
+	if failed != exitOK {
+		return failed
+	}
+
+	// Only fields passed on the command line are changed
+	project := &projects[index]
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			project.Name = changes.Name
+		case "path":
+			project.Path = linuxProjectPath(changes.Path)
+		case "command":
+			project.Command = changes.Command
+		case "link":
+			project.Link = changes.Link
+		case "category":
+			project.Category = changes.Category
+		case "log-file":
+			project.LogFile = changes.LogFile
+		case "platform":
+			project.Platform = changes.Platform
+		}
+	})
+
+	if failed := c.save(projects); failed != exitOK {
+		return failed
+	}
+	if c.json {
+		return c.printJSON(project)
+	}
+	fmt.Fprintf(c.stdout, "✅ Updated %s\n", project.Name)
+	return exitOK
+}
+
+func (c *cli) remove(args []string) int {
+	fs := c.flagSet("remove")
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	projects, index, failed := c.lookup(name)
+	if failed != exitOK {
+		return failed
+	}
+
+	removed := projects[index]
+	projects = append(projects[:index], projects[index+1:]...)
+	if failed := c.save(projects); failed != exitOK {
+		return failed
+	}
+	if c.json {
+		return c.printJSON(removed)
+	}
+	fmt.Fprintf(c.stdout, "🗑️ Removed %s\n", removed.Name)
+	return exitOK
+}
+
+func (c *cli) group(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(c.stderr, "usage: project-launcher group list|start|stop [name]")
+		return exitUsage
+	}
+	if c.settingsErr != nil {
+		// Groups live in settings.json, so none of them can be trusted
+		return c.fail(exitConfig, "groups unavailable: %v", c.settingsErr)
+	}
+	switch args[0] {
+	case "list", "ls":
+		return c.groupList(args[1:])
+	case "start", "up":
+		return c.groupStart(args[1:])
+	case "stop", "down":
+		return c.groupStop(args[1:])
+	}
+	fmt.Fprintf(c.stderr, "unknown group command %q\n", args[0])
+	return exitUsage
+}
+
+// lookupGroup loads the projects and resolves the named group in launch order
+func (c *cli) lookupGroup(name string) ([]groupNode, int) {
+	group, ok := c.settings.findGroup(name)
+	if !ok {
+		return nil, c.fail(exitNotFound, "no group named %q", name)
+	}
+	projects, failed := c.load()
+	if failed != exitOK {
+		return nil, failed
+	}
+	nodes, err := resolveGroup(group, projects)
+	if err != nil {
+		return nil, c.fail(exitConfig, "%v", err)
+	}
+	return nodes, exitOK
+}
+
+func (c *cli) groupList(args []string) int {
+	fs := c.flagSet("group list")
+	if _, err := parseArgs(fs, args); err != nil {
+		return exitUsage
+	}
+	projects, failed := c.load()
+	if failed != exitOK {
+		return failed
+	}
+
+	type groupEntry struct {
+		Name     string   `json:"name"`
+		Projects []string `json:"projects"` // Launch order
+		Error    string   `json:"error,omitempty"`
+	}
+	entries := []groupEntry{}
+	for _, group := range c.settings.Groups {
+		entry := groupEntry{Name: group.Name, Projects: []string{}}
+		nodes, err := resolveGroup(group, projects)
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		for _, node := range nodes {
+			entry.Projects = append(entry.Projects, node.project.Name)
+		}
+		entries = append(entries, entry)
+	}
+	if c.json {
+		return c.printJSON(entries)
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tLAUNCH ORDER")
+	for _, entry := range entries {
+		order := strings.Join(entry.Projects, " → ")
+		if entry.Error != "" {
+			order = "error: " + entry.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\n", entry.Name, order)
+	}
+	w.Flush()
+	return exitOK
+}
+
+func (c *cli) groupStart(args []string) int {
+	fs := c.flagSet("group start")
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	nodes, failed := c.lookupGroup(name)
+	if failed != exitOK {
+		return failed
+	}
+
+	// Projects start one at a time, so every dependency is up before its dependents
+	for _, node := range nodes {
+		project, err := node.project.withProfile("")
+		if err != nil {
+			return c.fail(exitConfig, "%v", err)
+		}
+		if record, ok := readRunRecord(processKey(project)); ok && record.alive() {
+			fmt.Fprintf(c.stdout, "✅ %s already running (pid %d)\n", project.Name, record.PID)
+			continue
+		}
+		pid, logPath, err := c.startDetached(project)
+		if err != nil {
+			return c.fail(exitError, "%v", err)
+		}
+		if project.Ready != nil {
+			fmt.Fprintf(c.stdout, "⏳ %s waiting for %s\n", project.Name, project.Ready.describe())
+		}
+		if err := c.waitReady(project, logPath); err != nil {
+			return c.fail(exitError, "%s %v, see %s", project.Name, err, logPath)
+		}
+		fmt.Fprintf(c.stdout, "🚀 %s ready (pid %d)\n", project.Name, pid)
+	}
+	return exitOK
+}
+
+// waitReady waits until a project started by the CLI passes its readiness
+// probe or, without one, has stayed up long enough for its dependents to start
+func (c *cli) waitReady(project Project, logPath string) error {
+	// Start-Process launches only leave a PowerShell wrapper that exits once
+	// the program is up, so the wrapper's exit says nothing
+	detached := project.startsDetached()
+	if project.Ready != nil {
+		prober, err := newReadyProber(*project.Ready, project.Path, logPath)
+		if err != nil {
+			return err
+		}
+		return prober.waitReady(func() bool {
+			record, ok := readRunRecord(processKey(project))
+			return detached || (ok && record.alive())
+		})
+	}
+	if detached {
+		return nil
+	}
+
+	deadline := time.Now().Add(groupSettle)
+	for time.Now().Before(deadline) {
+		record, ok := readRunRecord(processKey(project))
+		if !ok || !record.alive() {
+			return errProbeExited
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return nil
+}
+
+func (c *cli) groupStop(args []string) int {
+	fs := c.flagSet("group stop")
+	name, ok := c.parseNamed(fs, args)
+	if !ok {
+		return exitUsage
+	}
+	nodes, failed := c.lookupGroup(name)
+	if failed != exitOK {
+		return failed
+	}
+
+	code := exitOK
+	for i := len(nodes) - 1; i >= 0; i-- {
+		project := nodes[i].project
+		record, ok := readRunRecord(processKey(project))
+		if !ok || !record.alive() {
+			continue
+		}
+		killed, err := c.stopRecord(project, record)
+		switch {
+		case err != nil:
+			c.fail(exitError, "failed to stop %s: %v", project.Name, err)
+			code = exitError
+		case killed:
+			fmt.Fprintf(c.stdout, "💀 Killed %s after %s grace period\n", project.Name, c.settings.stopGrace())
+		default:
+			fmt.Fprintf(c.stdout, "⏹️ Stopped %s\n", project.Name)
+		}
+	}
+	return code
+}
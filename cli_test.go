@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestCLI returns a cli working on a config file in a temp directory that
+// holds projects, with state kept in another one
+func newTestCLI(t *testing.T, projects []Project) (*cli, *bytes.Buffer) {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	store := newProjectStore(configFile, 0)
+	if err := store.Save(projects); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	return &cli{configFile: configFile, store: store, stdout: &stdout, stderr: &bytes.Buffer{}}, &stdout
+}
+
+func TestSuperviseTagsOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		project   Project
+		wantLines map[string]string // Stream of each output line
+		wantErr   bool
+	}{
+		{"output is tagged", Project{ID: "p1", Name: "App", Command: "echo out; echo err >&2"},
+			map[string]string{"out": "stdout", "err": "stderr", "=== Exited with code 0 ===": "launcher"}, false},
+		{"launch failure is reported", Project{ID: "p1", Name: "App", Command: "true", EnvFiles: []string{"missing-equals.env"}},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.project.Path = t.TempDir()
+			os.WriteFile(filepath.Join(tt.project.Path, "missing-equals.env"), []byte("nope\n"), 0o644)
+			c, stdout := newTestCLI(t, []Project{tt.project})
+
+			c.supervise([]string{tt.project.ID, "--profile", ""})
+			var started supervisorStart
+			if err := json.Unmarshal(stdout.Bytes(), &started); err != nil {
+				t.Fatalf("report %q: %v", stdout.String(), err)
+			}
+			if (started.Error != "") != tt.wantErr {
+				t.Fatalf("report error %q, want error %v", started.Error, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if started.PID == 0 {
+				t.Error("report has no pid")
+			}
+
+			file, err := os.Open(started.LogPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got := make(map[string]string)
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				stream, text, ok := parseLogLine(scanner.Text())
+				if !ok {
+					t.Errorf("untagged log line %q", scanner.Text())
+				}
+				got[text] = stream
+			}
+			for text, stream := range tt.wantLines {
+				if got[text] != stream {
+					t.Errorf("line %q logged as %q, want %q", text, got[text], stream)
+				}
+			}
+			if _, ok := readRunRecord(processKey(tt.project)); ok {
+				t.Error("run record left behind after the project exited")
+			}
+		})
+	}
+}
+
+func TestRunWaitOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantStdout string
+		wantStderr string
+	}{
+		{"plain", []string{"App", "--wait"}, "out\n", "err\n"},
+		{"json keeps stdout parseable", []string{"App", "--wait", "--json"}, `"exit_code": 3`, "out\nerr\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := Project{ID: "p1", Name: "App", Path: t.TempDir(), Command: "echo out; sleep 0.1; echo err >&2; exit 3"}
+			c, stdout := newTestCLI(t, []Project{project})
+			var stderr bytes.Buffer
+			c.stderr = &stderr
+
+			if code := c.run(tt.args); code != 3 {
+				t.Errorf("exit code %d, want 3", code)
+			}
+			if got := stdout.String(); !strings.Contains(got, tt.wantStdout) {
+				t.Errorf("stdout %q does not contain %q", got, tt.wantStdout)
+			}
+			if c.json && !json.Valid(stdout.Bytes()) {
+				t.Errorf("stdout is not JSON: %q", stdout.String())
+			}
+			if got := stderr.String(); got != tt.wantStderr {
+				t.Errorf("stderr %q, want %q", got, tt.wantStderr)
+			}
+		})
+	}
+}
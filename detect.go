@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// commandSuggestion is a launch command proposed for a project directory
+type commandSuggestion struct {
+	Command string
+	Reason  string // Where the suggestion comes from, shown while cycling
+	score   int    // Higher ranks first
+}
+
+// commandDetector inspects a project directory and proposes commands
+type commandDetector func(dir string) []commandSuggestion
+
+// commandDetectors run in this order; ties in score keep this order
+var commandDetectors = []commandDetector{
+	detectNode,
+	detectGo,
+	detectRust,
+	detectPython,
+	detectCompose,
+	detectMake,
+}
+
+// detectCommands returns launch command suggestions for dir, best first
+func detectCommands(dir string) []commandSuggestion {
+	dir = expandHome(dir)
+	var suggestions []commandSuggestion
+	seen := make(map[string]bool)
+	for _, detect := range commandDetectors {
+		for _, s := range detect(dir) {
+			if !seen[s.Command] {
+				seen[s.Command] = true
+				suggestions = append(suggestions, s)
+			}
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].score > suggestions[j].score
+	})
+	return suggestions
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// nodePackageManager picks the package manager from the lockfile present
+func nodePackageManager(dir string) string {
+	switch {
+	case fileExists(filepath.Join(dir, "pnpm-lock.yaml")):
+		return "pnpm"
+	case fileExists(filepath.Join(dir, "yarn.lock")):
+		return "yarn"
+	case fileExists(filepath.Join(dir, "bun.lockb")), fileExists(filepath.Join(dir, "bun.lock")):
+		return "bun"
+	}
+	return "npm"
+}
+
+// runScriptCommand is how a package manager runs a package.json script
+func runScriptCommand(manager, script string) string {
+	switch {
+	case manager == "npm" && script == "start":
+		return "npm start"
+	case manager == "npm", manager == "bun":
+		return manager + " run " + script
+	}
+	return manager + " " + script
+}
+
+// packageScripts reads the script names from package.json, sorted
+func packageScripts(dir string) ([]string, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, false
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, true
+	}
+	scripts := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		scripts = append(scripts, name)
+	}
+	sort.Strings(scripts)
+	return scripts, true
+}
+
+// nodeScriptScores ranks the scripts that usually start a dev server
+var nodeScriptScores = map[string]int{"dev": 90, "start": 85, "serve": 80, "preview": 60, "watch": 55}
+
+func detectNode(dir string) []commandSuggestion {
+	scripts, ok := packageScripts(dir)
+	if !ok {
+		return nil
+	}
+	manager := nodePackageManager(dir)
+	var suggestions []commandSuggestion
+	for _, script := range scripts {
+		score, ok := nodeScriptScores[script]
+		if !ok {
+			score = 30
+		}
+		suggestions = append(suggestions, commandSuggestion{
+			Command: runScriptCommand(manager, script),
+			Reason:  "package.json script (" + manager + ")",
+			score:   score,
+		})
+	}
+	if len(suggestions) == 0 {
+		suggestions = append(suggestions, commandSuggestion{Command: "node .", Reason: "package.json without scripts", score: 20})
+	}
+	return suggestions
+}
+
+var goPackageMain = regexp.MustCompile(`(?m)^package main\b`)
+
+// hasGoMain reports whether dir holds a Go main package
+func hasGoMain(dir string) bool {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if data, err := os.ReadFile(file); err == nil && goPackageMain.Match(data) {
+			return true
+		}
+	}
+	return false
+}
+
+func detectGo(dir string) []commandSuggestion {
+	if !fileExists(filepath.Join(dir, "go.mod")) {
+		return nil
+	}
+	var suggestions []commandSuggestion
+	if hasGoMain(dir) {
+		suggestions = append(suggestions, commandSuggestion{Command: "go run .", Reason: "go.mod with main package", score: 80})
+	}
+	cmds, _ := filepath.Glob(filepath.Join(dir, "cmd", "*"))
+	for _, cmd := range cmds {
+		if hasGoMain(cmd) {
+			suggestions = append(suggestions, commandSuggestion{
+				Command: "go run ./cmd/" + filepath.Base(cmd),
+				Reason:  "main package in cmd/",
+				score:   70,
+			})
+		}
+	}
+	if len(suggestions) == 0 {
+		suggestions = append(suggestions, commandSuggestion{Command: "go run .", Reason: "go.mod", score: 50})
+	}
+	return suggestions
+}
+
+func detectRust(dir string) []commandSuggestion {
+	if !fileExists(filepath.Join(dir, "Cargo.toml")) {
+		return nil
+	}
+	return []commandSuggestion{
+		{Command: "cargo run", Reason: "Cargo.toml", score: 80},
+		{Command: "cargo run --release", Reason: "Cargo.toml", score: 40},
+	}
+}
+
+// tomlKeys returns the keys defined directly in each [section] of a TOML
+// file. It only understands what's needed to find script tables.
+func tomlKeys(data []byte) map[string][]string {
+	keys := make(map[string][]string)
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "["):
+			section = strings.Trim(line, "[] ")
+			if _, ok := keys[section]; !ok {
+				keys[section] = nil
+			}
+		default:
+			if key, _, ok := strings.Cut(line, "="); ok {
+				key = strings.Trim(strings.TrimSpace(key), `"'`)
+				if key != "" && !strings.ContainsAny(key, " ]") {
+					keys[section] = append(keys[section], key)
+				}
+			}
+		}
+	}
+	return keys
+}
+
+func detectPython(dir string) []commandSuggestion {
+	data, err := os.ReadFile(filepath.Join(dir, "pyproject.toml"))
+	if err != nil {
+		return nil
+	}
+	sections := tomlKeys(data)
+
+	// Run through the project's environment manager when it has one
+	runner, python := "", "python3"
+	if _, ok := sections["tool.poetry"]; ok || fileExists(filepath.Join(dir, "poetry.lock")) {
+		runner, python = "poetry run ", "python"
+	} else if fileExists(filepath.Join(dir, "uv.lock")) {
+		runner, python = "uv run ", "python"
+	}
+
+	var suggestions []commandSuggestion
+	for _, section := range []string{"project.scripts", "tool.poetry.scripts"} {
+		for _, script := range sections[section] {
+			suggestions = append(suggestions, commandSuggestion{Command: runner + script, Reason: "pyproject.toml script", score: 75})
+		}
+	}
+	if bytes.Contains(data, []byte("fastapi")) || bytes.Contains(data, []byte("uvicorn")) {
+		for _, app := range []struct{ file, module string }{
+			{"main.py", "main:app"},
+			{"app/main.py", "app.main:app"},
+			{"src/main.py", "src.main:app"},
+		} {
+			if fileExists(filepath.Join(dir, app.file)) {
+				suggestions = append(suggestions, commandSuggestion{Command: runner + "uvicorn " + app.module + " --reload", Reason: "ASGI app in " + app.file, score: 78})
+				break
+			}
+		}
+	}
+	if fileExists(filepath.Join(dir, "manage.py")) {
+		suggestions = append(suggestions, commandSuggestion{Command: runner + python + " manage.py runserver", Reason: "Django manage.py", score: 78})
+	}
+	if fileExists(filepath.Join(dir, "main.py")) {
+		suggestions = append(suggestions, commandSuggestion{Command: runner + python + " main.py", Reason: "main.py", score: 60})
+	}
+	module := strings.ReplaceAll(filepath.Base(dir), "-", "_")
+	suggestions = append(suggestions, commandSuggestion{Command: runner + python + " -m " + module, Reason: "pyproject.toml", score: 40})
+	return suggestions
+}
+
+// composeFiles are the file names docker compose picks up by default
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+func detectCompose(dir string) []commandSuggestion {
+	for _, name := range composeFiles {
+		if fileExists(filepath.Join(dir, name)) {
+			return []commandSuggestion{{Command: "docker compose up", Reason: name, score: 70}}
+		}
+	}
+	return nil
+}
+
+// makeTarget matches rule lines, including double-colon rules, but not the
+// :=, ::= and :::= variable assignments
+var makeTarget = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_.-]*)\s*::?([^:=]|$)`)
+
+// makefileTargets lists the explicit targets of a Makefile in file order,
+// skipping special and pattern targets
+func makefileTargets(dir string) ([]string, bool) {
+	var data []byte
+	var err error
+	for _, name := range []string{"GNUmakefile", "makefile", "Makefile"} {
+		if data, err = os.ReadFile(filepath.Join(dir, name)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, false
+	}
+	var targets []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		match := makeTarget.FindStringSubmatch(scanner.Text())
+		if match == nil || seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		targets = append(targets, match[1])
+	}
+	return targets, true
+}
+
+// makeTargetScores ranks the targets that usually start the project
+var makeTargetScores = map[string]int{"run": 65, "dev": 65, "start": 65, "serve": 60, "up": 55}
+
+func detectMake(dir string) []commandSuggestion {
+	targets, ok := makefileTargets(dir)
+	if !ok {
+		return nil
+	}
+	suggestions := []commandSuggestion{{Command: "make", Reason: "Makefile default target", score: 45}}
+	for _, target := range targets {
+		if score, ok := makeTargetScores[target]; ok {
+			suggestions = append(suggestions, commandSuggestion{Command: "make " + target, Reason: "Makefile target", score: score})
+		}
+	}
+	return suggestions
+}
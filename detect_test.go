@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMakefileTargets(t *testing.T) {
+	tests := []struct {
+		name     string
+		makefile string
+		want     []string
+	}{
+		{"rules", "build: deps\n\tgo build\nrun:\n\t./app\n", []string{"build", "run"}},
+		{"double-colon rule", "clean::\n\trm -f app\n", []string{"clean"}},
+		{"assignments", "CC ::= gcc\nLD :::= ld\nX := 1\nY = 2\nZ ?= 3\nW += 4\nV != date\nserve: ; ./serve\n", []string{"serve"}},
+		{"no space before assignment", "CC::=gcc\nCFLAGS:=-O2\ndev:\n", []string{"dev"}},
+		{"special and pattern targets", ".PHONY: run\n%.o: %.c\nrun:\n", []string{"run"}},
+		{"duplicates", "up: a\nup: b\n", []string{"up"}},
+		{"recipe lines", "run:\n\tdocker: compose up\n", []string{"run"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "Makefile"), []byte(tt.makefile), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, ok := makefileTargets(dir)
+			if !ok {
+				t.Fatal("Makefile not found")
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("makefileTargets() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoveryDropsStaleResults(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "app", ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		reopen    bool // Close and reopen the checklist before the first scan finishes
+		wantFirst bool // Whether the first scan's result is applied
+	}{
+		{"current scan", false, true},
+		{"scan from before a reopen", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(nil)
+			m.settings.DiscoveryRoots = []string{root}
+			first := m.openDiscovery()().(discoveryResultMsg)
+			if tt.reopen {
+				m.closeDiscovery()
+				m.openDiscovery()
+			}
+			first.candidates = []candidate{{Project: Project{Name: "stale"}}}
+
+			updated, _ := m.Update(first)
+			got := updated.(model).discovery
+			if applied := !got.scanning; applied != tt.wantFirst {
+				t.Errorf("first scan applied = %v, want %v", applied, tt.wantFirst)
+			}
+		})
+	}
+}
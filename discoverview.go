@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// discoveryView is a checklist of projects found under the discovery roots
+type discoveryView struct {
+	roots      []string
+	scanning   bool
+	candidates []candidate
+	cursor     int
+	err        error // Scan error, e.g. a root that doesn't exist
+	scan       *int  // Identifies the running scan; results of earlier ones are dropped
+}
+
+// discoveryResultMsg carries the result of a background scan
+type discoveryResultMsg struct {
+	scan       *int
+	candidates []candidate
+	err        error
+}
+
+// openDiscovery starts scanning the discovery roots in the background
+func (m *model) openDiscovery() tea.Cmd {
+	roots := m.settings.discoveryRoots()
+	if len(roots) == 0 {
+		return showStatus("🔎 No folders to scan, set discovery_roots in settings.json")
+	}
+	scan := new(int)
+	m.discovery = discoveryView{roots: roots, scanning: true, scan: scan}
+	m.discoverMode = true
+
+	depth, ignore := m.settings.discoveryDepth(), m.settings.discoveryIgnore()
+	existing := m.snapshotProjects()
+	return func() tea.Msg {
+		candidates, err := discoverProjects(roots, depth, ignore, existing)
+		return discoveryResultMsg{scan: scan, candidates: candidates, err: err}
+	}
+}
+
+func (m *model) closeDiscovery() {
+	m.discoverMode = false
+	m.discovery = discoveryView{}
+}
+
+// importCandidates adds the checked candidates to the project list as one
+// undoable change
+func (m *model) importCandidates() tea.Cmd {
+	before := m.snapshotProjects()
+	imported := 0
+	for _, c := range m.discovery.candidates {
+		if !c.selected {
+			continue
+		}
+		project := c.Project
+		project.ID = newProjectID()
+		m.projects = append(m.projects, project)
+		imported++
+	}
+	if imported == 0 {
+		return showStatus("🔎 Nothing selected to import")
+	}
+	if err := m.saveProjects(); err != nil {
+		m.projects = before
+		return saveFailed(err)
+	}
+	m.recordChange(fmt.Sprintf("import of %d projects", imported), before)
+	m.updateTable()
+	m.closeDiscovery()
+	return showStatus(fmt.Sprintf("📥 Imported %d projects", imported))
+}
+
+func (m model) updateDiscovery(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := &m.discovery
+	switch msg.String() {
+	case "q", "esc":
+		m.closeDiscovery()
+		return m, nil
+	case "up", "k":
+		d.cursor = max(d.cursor-1, 0)
+	case "down", "j":
+		d.cursor = min(d.cursor+1, max(len(d.candidates)-1, 0))
+	case " ", "x":
+		if d.cursor < len(d.candidates) {
+			d.candidates[d.cursor].selected = !d.candidates[d.cursor].selected
+		}
+	case "a":
+		// Select all, or clear all when everything is already selected
+		all := true
+		for _, c := range d.candidates {
+			all = all && c.selected
+		}
+		for i := range d.candidates {
+			d.candidates[i].selected = !all
+		}
+	case "enter":
+		if d.scanning {
+			return m, nil
+		}
+		return m, m.importCandidates()
+	}
+	return m, nil
+}
+
+func (m model) viewDiscovery(statusMessage string) string {
+	d := m.discovery
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).
+		Render("🔎 Discover Projects")
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	roots := dimStyle.Render("Scanning " + strings.Join(d.roots, ", "))
+
+	var lines []string
+	switch {
+	case d.scanning:
+		lines = append(lines, "Scanning...")
+	case len(d.candidates) == 0:
+		lines = append(lines, "No new projects found.")
+	default:
+		selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+		width := max(m.width-2, 40)
+		listHeight := max(m.height-9, 3)
+		start, end := visibleWindow(d.cursor, len(d.candidates), listHeight)
+		for i := start; i < end; i++ {
+			c := d.candidates[i]
+			check := "[ ]"
+			if c.selected {
+				check = "[x]"
+			}
+			line := fmt.Sprintf("%s %-24s %-8s %-20s %s",
+				check,
+				runewidth.Truncate(c.Name, 24, "…"),
+				runewidth.Truncate(c.Category, 8, "…"),
+				runewidth.Truncate(c.Command, 20, "…"),
+				c.Path)
+			line = runewidth.Truncate(line, width, "…")
+			if i == d.cursor {
+				line = selectedStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+	}
+	if d.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		lines = append(lines, errStyle.Render(fmt.Sprintf("❌ %v", d.err)))
+	}
+
+	selected := 0
+	for _, c := range d.candidates {
+		if c.selected {
+			selected++
+		}
+	}
+	hints := renderKeyHints([]keyHint{
+		{"↑↓", "navigate"},
+		{"space", "toggle"},
+		{"a", "toggle all"},
+		{"enter", fmt.Sprintf("import %d selected", selected)},
+		{"esc", "cancel"},
+	})
+
+	return fmt.Sprintf("%s\n%s\n\n%s\n\n%s\n%s", header, roots, strings.Join(lines, "\n"), hints, statusMessage)
+}
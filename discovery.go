@@ -0,0 +1,134 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// projectMarker is a file or directory whose presence makes a directory a
+// project, with the category suggested for it
+type projectMarker struct {
+	name     string
+	category string
+}
+
+// projectMarkers in order of preference when suggesting a category
+var projectMarkers = []projectMarker{
+	{"go.mod", "Go"},
+	{"Cargo.toml", "Rust"},
+	{"package.json", "Node"},
+	{"pyproject.toml", "Python"},
+	{"docker-compose.yml", "Docker"},
+	{"docker-compose.yaml", "Docker"},
+	{"Makefile", ""},
+	{".git", ""},
+}
+
+// candidate is a project found while scanning that isn't configured yet
+type candidate struct {
+	Project
+	markers  []string
+	selected bool
+}
+
+// discoverProjects walks each root up to depth levels deep and returns the
+// directories containing a project marker that are not already configured.
+// A project's subdirectories are not scanned, so nested packages of a
+// monorepo don't show up as separate projects.
+func discoverProjects(roots []string, depth int, ignore []string, existing []Project) ([]candidate, error) {
+	known := make(map[string]bool, len(existing))
+	for _, project := range existing {
+		known[filepath.Clean(expandHome(project.Path))] = true
+	}
+
+	var candidates []candidate
+	var firstErr error
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// Unreadable directories are skipped, a missing root is reported
+				if path == root {
+					return err
+				}
+				return fs.SkipDir
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(root, path)
+			if path != root && ignoredDir(rel, d.Name(), ignore) {
+				return fs.SkipDir
+			}
+
+			markers := projectMarkersIn(path)
+			if len(markers) > 0 {
+				if !known[path] {
+					known[path] = true
+					candidates = append(candidates, newCandidate(path, markers))
+				}
+				return fs.SkipDir
+			}
+			if rel != "." && strings.Count(rel, string(filepath.Separator))+1 >= depth {
+				return fs.SkipDir
+			}
+			return nil
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Path < candidates[j].Path
+	})
+	return candidates, firstErr
+}
+
+// ignoredDir reports whether a directory matches one of the ignore globs,
+// tried against its name and its path relative to the scanned root
+func ignoredDir(rel, name string, ignore []string) bool {
+	for _, pattern := range ignore {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// projectMarkersIn lists the project markers present in dir
+func projectMarkersIn(dir string) []string {
+	var markers []string
+	for _, marker := range projectMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker.name)); err == nil {
+			markers = append(markers, marker.name)
+		}
+	}
+	return markers
+}
+
+// newCandidate suggests a name, category and command for a found project
+func newCandidate(dir string, markers []string) candidate {
+	c := candidate{
+		Project:  Project{Name: filepath.Base(dir), Path: dir},
+		markers:  markers,
+		selected: true,
+	}
+	for _, marker := range projectMarkers {
+		if marker.category != "" && slices.Contains(markers, marker.name) {
+			c.Category = marker.category
+			break
+		}
+	}
+	if suggestions := detectCommands(dir); len(suggestions) > 0 {
+		c.Command = suggestions[0].Command
+	}
+	return c
+}
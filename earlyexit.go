@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// earlyExitLines is how many lines of output an early exit shows
+const earlyExitLines = 3
+
+// launchFailure is a launch that exited with an error within the early exit
+// window. It stays on screen until dismissed or the project is relaunched.
+type launchFailure struct {
+	key   string
+	name  string
+	pid   int
+	code  int
+	after time.Duration // How long the process ran
+	tail  []string      // Last stderr lines, or stdout when stderr was empty
+}
+
+// earlyExit reports a failed launch when the process behind msg exited with a
+// non-zero code within the early exit window
+func (m model) earlyExit(msg processExitedMsg) *launchFailure {
+	info, ok := m.processes.get(msg.key)
+	if !ok || info.PID != msg.pid || info.State == procStopped || msg.exitCode == 0 {
+		return nil
+	}
+	after := info.ExitedAt.Sub(info.StartedAt)
+	if after > m.settings.earlyExitWindow() {
+		return nil
+	}
+
+	failure := &launchFailure{key: msg.key, pid: msg.pid, code: msg.exitCode, after: after}
+	for _, project := range m.projects {
+		if processKey(project) == msg.key {
+			failure.name = project.Name
+		}
+	}
+	failure.tail = launchTail(info.LogPath, earlyExitLines, "stderr")
+	if len(failure.tail) == 0 {
+		failure.tail = launchTail(info.LogPath, earlyExitLines, "stdout")
+	}
+	return failure
+}
+
+// activeFailure returns the launch failure to show, if the project hasn't
+// been relaunched since
+func (m model) activeFailure() *launchFailure {
+	if m.failure == nil {
+		return nil
+	}
+	info, ok := m.processes.get(m.failure.key)
+	if !ok || info.PID != m.failure.pid || info.State == procRunning {
+		return nil
+	}
+	return m.failure
+}
+
+// showFailureOutput opens the log of the failed launch at its start
+func (m *model) showFailureOutput() {
+	failure := m.activeFailure()
+	if failure == nil {
+		return
+	}
+	for _, project := range m.projects {
+		if processKey(project) == failure.key {
+			m.openLogs(project)
+			m.logs.scrollToLastLaunch()
+			return
+		}
+	}
+}
+
+// view renders the failure in place of the status line
+func (f launchFailure) view(width int) string {
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{" > " + errStyle.Render(fmt.Sprintf("❌ %s exited with code %d after %s", f.name, f.code, f.after.Round(time.Millisecond)))}
+	for _, line := range f.tail {
+		lines = append(lines, dimStyle.Render(ansi.Truncate("   │ "+line, max(width, 20), "…")))
+	}
+	lines = append(lines, "   "+renderKeyHints([]keyHint{{"L", "full output"}, {"esc", "dismiss"}}))
+	return strings.Join(lines, "\n")
+}
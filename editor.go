@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// terminalEditors take over the terminal, so they run in the foreground with
+// the launcher suspended. Anything else is started as a detached GUI app.
+var terminalEditors = []string{
+	"vi", "vim", "nvim", "nano", "micro", "hx", "helix", "kak", "emacs", "emacsclient",
+	"joe", "ne", "mg", "jed", "mcedit", "ed", "pico",
+}
+
+// vsCodeEditors understand --remote for opening WSL directories from Windows
+var vsCodeEditors = []string{"code", "code-insiders", "codium", "cursor"}
+
+var errNoEditor = errors.New("no editor configured: set $VISUAL or $EDITOR, \"editor\" in settings.json, or install code")
+
+// editorExitedMsg reports the end of a terminal editor
+type editorExitedMsg struct {
+	name string
+	err  error
+}
+
+// editorCommand picks the editor for a project: its own, the one in the
+// settings, $VISUAL, $EDITOR, then VS Code when it is installed
+func (m model) editorCommand(project Project) ([]string, error) {
+	for _, editor := range []string{project.Editor, m.settings.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if fields := strings.Fields(editor); len(fields) > 0 {
+			return fields, nil
+		}
+	}
+	if _, err := exec.LookPath("code"); err == nil {
+		return []string{"code"}, nil
+	}
+	return nil, errNoEditor
+}
+
+// isTerminalEditor reports whether editor runs inside the terminal.
+// "emacs -nw" is, plain emacs only when there is no display to open a window on.
+func isTerminalEditor(editor []string) bool {
+	name := filepath.Base(editor[0])
+	if name == "emacs" {
+		for _, arg := range editor[1:] {
+			if arg == "-nw" || arg == "--no-window-system" || arg == "-t" || arg == "--tty" {
+				return true
+			}
+		}
+		return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+	}
+	return slices.Contains(terminalEditors, name)
+}
+
+// editorArgs adds the project directory to the editor command. Under WSL,
+// VS Code opens Linux directories through Remote-WSL, and folders on Windows
+// drives in the Windows-side editor started by cmd.exe, so neither goes
+// through the \\wsl$ share.
+func editorArgs(editor []string, path string) []string {
+	args := slices.Clone(editor)
+	if !runningInWSL() || !slices.Contains(vsCodeEditors, filepath.Base(editor[0])) {
+		return append(args, path)
+	}
+	if windowsPath, ok := toWindowsPath(path); ok {
+		args[0] = filepath.Base(args[0])
+		return append(append([]string{"cmd.exe", "/c"}, args...), windowsPath)
+	}
+	if distro := os.Getenv("WSL_DISTRO_NAME"); distro != "" {
+		return append(args, "--remote", "wsl+"+distro, path)
+	}
+	return append(args, path)
+}
+
+// openInEditor opens the project directory in its editor
+func (m model) openInEditor(project Project) tea.Cmd {
+	editor, err := m.editorCommand(project)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ %v", err))
+	}
+	args := editorArgs(editor, project.Path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = project.Path
+
+	if isTerminalEditor(editor) {
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return editorExitedMsg{name: project.Name, err: err}
+		})
+	}
+
+	// GUI editors outlive the launcher, so they get their own process group
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return showStatus(fmt.Sprintf("❌ Failed to open %s in %s: %v", project.Name, editor[0], err))
+	}
+	go cmd.Wait() // Reap it; editors like code exit as soon as the window is up
+	return showStatus(fmt.Sprintf("📝 Opened %s in %s", project.Name, filepath.Base(editor[0])))
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadDotenv(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []dotenvEntry
+		wantErr bool
+	}{
+		{"plain", "A=1\nB = two words \n", []dotenvEntry{{Key: "A", Value: "1"}, {Key: "B", Value: "two words"}}, false},
+		{"comments and export", "# note\n\nexport PORT=8080 # dev port\n", []dotenvEntry{{Key: "PORT", Value: "8080"}}, false},
+		{"single quotes are literal", "P='a $b # c'\n", []dotenvEntry{{Key: "P", Value: "a $b # c", literal: true}}, false},
+		{"double quote escapes", `M="a\tb \"c\" \\ d"` + "\n", []dotenvEntry{{Key: "M", Value: "a\tb \"c\" \\ d"}}, false},
+		{"multiline", "KEY=\"line1\nline2\"\nNEXT=x\n", []dotenvEntry{{Key: "KEY", Value: "line1\nline2"}, {Key: "NEXT", Value: "x"}}, false},
+		{"dollar kept", "DB_PASS=pa$$w0rd\n", []dotenvEntry{{Key: "DB_PASS", Value: "pa$$w0rd"}}, false},
+		{"empty value", "EMPTY=\n", []dotenvEntry{{Key: "EMPTY", Value: ""}}, false},
+		{"missing equals", "JUSTAKEY\n", nil, true},
+		{"bad key", "1A=x\n", nil, true},
+		{"unterminated single", "A='x\n", nil, true},
+		{"unterminated double", "A=\"x\nB=y\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := readDotenv(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("readDotenv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	vars := map[string]string{"HOME": "/home/u", "PORT": "8080", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+	tests := []struct {
+		value, want string
+	}{
+		{"${HOME}/logs", "/home/u/logs"},
+		{"$HOME/logs", "/home/u/logs"},
+		{"localhost:$PORT", "localhost:8080"},
+		{"${PORT}0", "80800"},
+		{"x${EMPTY}y", "xy"},
+		{"pa$$w0rd", "pa$$w0rd"},
+		{"cost $5", "cost $5"},
+		{"a$1b", "a$1b"},
+		{"$UNSET and ${UNSET}", "$UNSET and ${UNSET}"},
+		{"${not valid}", "${not valid}"},
+		{"${HOME", "${HOME"},
+		{"trailing $", "trailing $"},
+		{`\$HOME`, "$HOME"},
+		{`C:\dir\$PORT`, `C:\dir$PORT`},
+		{`C:\dir\sub`, `C:\dir\sub`},
+	}
+	for _, tt := range tests {
+		if got := expandEnv(tt.value, lookup); got != tt.want {
+			t.Errorf("expandEnv(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLaunchEnv(t *testing.T) {
+	t.Setenv("PL_TEST_BASE", "launcher")
+	t.Setenv("PL_TEST_HIDDEN", "secret")
+	dir := t.TempDir()
+	files := map[string]string{
+		".env":       "PL_TEST_BASE=file\nFROM_FILE=${PL_TEST_BASE}-1\nPASS=pa$$w0rd\nLIT='$PL_TEST_BASE'\n",
+		".env.local": "FROM_FILE=local\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	base := Project{
+		Path:     dir,
+		EnvFiles: []string{".env", ".env.local", ".env.missing"},
+		Env:      map[string]string{"CONFIG": "$FROM_FILE/$PL_TEST_BASE", "PRICE": "cost $5", "RAW": "a$1b"},
+	}
+	profiled := base
+	profiled.profile = "dev"
+	profiled.profileEnv = map[string]string{"CONFIG": "profile", "FROM_PROFILE": "${CONFIG}!"}
+	clean := base
+	clean.CleanEnv = true
+	clean.EnvAllowlist = []string{"PL_TEST_B*"}
+
+	tests := []struct {
+		name    string
+		project Project
+		want    map[string]string // Value, with the source after a |
+		absent  []string
+	}{
+		{"files then config", base, map[string]string{
+			"PL_TEST_BASE":   "file|.env",
+			"FROM_FILE":      "local|.env.local",
+			"PASS":           "pa$$w0rd|.env",
+			"LIT":            "$PL_TEST_BASE|.env",
+			"CONFIG":         "local/file|config",
+			"PRICE":          "cost $5|config",
+			"RAW":            "a$1b|config",
+			"PL_TEST_HIDDEN": "secret|launcher",
+		}, nil},
+		{"profile wins", profiled, map[string]string{
+			"CONFIG":       "profile|profile dev",
+			"FROM_PROFILE": "profile!|profile dev",
+		}, nil},
+		{"clean env", clean, map[string]string{
+			"PL_TEST_BASE": "file|.env",
+		}, []string{"PL_TEST_HIDDEN", "HOME"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := launchEnv(tt.project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for _, v := range env {
+				got[v.Key] = v.Value + "|" + v.Source
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("%s = %q, want %q", key, got[key], want)
+				}
+			}
+			for _, key := range tt.absent {
+				if _, ok := got[key]; ok {
+					t.Errorf("%s is set, want it left out", key)
+				}
+			}
+		})
+	}
+
+	broken := base
+	broken.EnvFiles = []string{"bad.env"}
+	if err := os.WriteFile(filepath.Join(dir, "bad.env"), []byte("nope\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := launchEnv(broken); err == nil {
+		t.Error("launchEnv accepted a malformed env file")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// envPreview shows the environment a project would be launched with
+type envPreview struct {
+	project  Project // Resolved with its default profile
+	env      []envVar
+	viewport viewport.Model
+}
+
+// openEnvPreview resolves the environment of project's default profile
+func (m *model) openEnvPreview(project Project) tea.Cmd {
+	resolved, err := project.withProfile("")
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ %v", err))
+	}
+	env, err := launchEnv(resolved)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ Cannot build environment: %v", err))
+	}
+	m.envView = envPreview{
+		project:  resolved,
+		env:      env,
+		viewport: viewport.New(m.width, max(m.height-5, 3)),
+	}
+	m.envView.viewport.SetContent(m.envView.render())
+	m.envMode = true
+	return nil
+}
+
+// render lists the variables set by the project first, then the inherited ones
+func (v envPreview) render() string {
+	sourceStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var set, inherited []string
+	for _, e := range v.env {
+		line := e.Key + "=" + strings.ReplaceAll(maskedValue(e), "\n", `\n`)
+		if e.Source == "launcher" {
+			inherited = append(inherited, dimStyle.Render(line))
+		} else {
+			set = append(set, line+"  "+sourceStyle.Render("("+e.Source+")"))
+		}
+	}
+
+	var b strings.Builder
+	if len(set) == 0 {
+		b.WriteString(dimStyle.Render("No variables set by the project"))
+	} else {
+		b.WriteString(strings.Join(set, "\n"))
+	}
+	b.WriteString("\n\n")
+	if v.project.CleanEnv {
+		b.WriteString(fmt.Sprintf("Kept from the launcher (clean_env, allowlist %s):\n", strings.Join(v.project.envAllowlist(), " ")))
+	} else {
+		b.WriteString("Inherited from the launcher:\n")
+	}
+	b.WriteString(strings.Join(inherited, "\n"))
+	return b.String()
+}
+
+func (m model) updateEnvPreview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.envMode = false
+		m.envView = envPreview{}
+		return m, nil
+	case "enter":
+		project := m.envView.project
+		m.envMode = false
+		m.envView = envPreview{}
+		return m, m.launchProject(project)
+	}
+	var cmd tea.Cmd
+	m.envView.viewport, cmd = m.envView.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m model) viewEnvPreview(statusMessage string) string {
+	v := m.envView
+	title := "🌱 Environment: " + v.project.Name
+	if v.project.profile != "" {
+		title += " (" + v.project.profile + ")"
+	}
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).Render(title)
+	hints := renderKeyHints([]keyHint{
+		{"↑↓/pgup/pgdn", "scroll"},
+		{"enter", "launch"},
+		{"esc", "back"},
+	})
+	return fmt.Sprintf("%s\n%s\n%s\n%s", header, v.viewport.View(), hints, statusMessage)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+	"github.com/sahilm/fuzzy"
+)
+
+// Highlighting only toggles bold and underline so the selected row keeps its
+// background color behind matched characters
+const (
+	highlightOn  = "\x1b[1;4m"
+	highlightOff = "\x1b[22;24m"
+)
+
+// projectMatch is how a project matched the current filter
+type projectMatch struct {
+	score  int
+	fields map[int][]int // Row data column index → matched byte offsets
+}
+
+// filterFields returns the searchable fields in row data column order
+func filterFields(project Project) []string {
+	category := project.Category
+	if category == "" {
+		category = "N/A"
+	}
+	return []string{project.Name, project.Path, project.commandCell(), category, project.Link}
+}
+
+// matchProject fuzzy-matches pattern against every searchable field. The
+// project's score is that of its best matching field.
+func matchProject(pattern string, project Project) (projectMatch, bool) {
+	match := projectMatch{fields: make(map[int][]int)}
+	found := false
+	for i, field := range filterFields(project) {
+		results := fuzzy.Find(pattern, []string{field})
+		if len(results) == 0 {
+			continue
+		}
+		if !found || results[0].Score > match.score {
+			match.score = results[0].Score
+		}
+		match.fields[i] = results[0].MatchedIndexes
+		found = true
+	}
+	return match, found
+}
+
+// highlightCell marks the matched bytes of text, keeping the result within
+// width. The table truncates cells by counting escape codes as visible
+// characters, so highlight runs that don't fit are dropped rather than cut.
+func highlightCell(text string, matched []int, width int) string {
+	if runewidth.StringWidth(text) > width {
+		text = runewidth.Truncate(text, width, "…")
+	}
+
+	hit := make(map[int]bool, len(matched))
+	for _, i := range matched {
+		hit[i] = true
+	}
+
+	// Group matched offsets into contiguous runs
+	type run struct{ start, end int }
+	var runs []run
+	for i, r := range text {
+		if !hit[i] {
+			continue
+		}
+		end := i + len(string(r))
+		if n := len(runs); n > 0 && runs[n-1].end == i {
+			runs[n-1].end = end
+		} else {
+			runs = append(runs, run{i, end})
+		}
+	}
+
+	overhead := len(highlightOn) + len(highlightOff) - 2 // ESC has no width
+	for len(runs) > 0 && runewidth.StringWidth(text)+len(runs)*overhead > width {
+		runs = runs[:len(runs)-1]
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, r := range runs {
+		b.WriteString(text[last:r.start])
+		b.WriteString(highlightOn)
+		b.WriteString(text[r.start:r.end])
+		b.WriteString(highlightOff)
+		last = r.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// filteredProjects returns the indices into sorted of projects matching the
+// filter, along with their matches, best match first
+func filteredProjects(pattern string, sorted []Project) ([]int, map[int]projectMatch) {
+	matches := make(map[int]projectMatch)
+	var ranked []int
+	for i, project := range sorted {
+		if match, ok := matchProject(pattern, project); ok {
+			matches[i] = match
+			ranked = append(ranked, i)
+		}
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		return matches[ranked[a]].score > matches[ranked[b]].score
+	})
+	return ranked, matches
+}
+
+func (m *model) clearFilter() {
+	m.filterMode = false
+	m.filter = ""
+	m.filterInput.Blur()
+	m.filterInput.SetValue("")
+	m.updateTable()
+}
+
+func (m model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.clearFilter()
+		return m, nil
+	case "enter":
+		if m.filterTop < 0 {
+			return m, showStatus("🔍 No matching project")
+		}
+		project := m.getProjectByDisplayIndex(m.filterTop)
+		if project == nil {
+			return m, nil
+		}
+		launched := *project
+		m.clearFilter()
+		m.table.SetCursor(m.findProjectDisplayIndex(launched))
+		return m, m.launchProfile(launched, "")
+	case "tab":
+		// Keep the filter but hand the keys back to the table
+		m.filterMode = false
+		m.filterInput.Blur()
+		return m, nil
+	case "up", "down", "pgup", "pgdown":
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	if value := m.filterInput.Value(); value != m.filter {
+		m.filter = value
+		m.updateTable()
+		if m.filterTop >= 0 {
+			m.table.SetCursor(m.filterTop)
+		} else {
+			m.table.SetCursor(0)
+		}
+	}
+	return m, cmd
+}
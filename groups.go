@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Group is a named set of projects launched together, each after the
+// projects it depends on are ready
+type Group struct {
+	Name    string        `json:"name"`
+	Members []GroupMember `json:"projects"`
+}
+
+// GroupMember is a project in a group, referenced by name or ID
+type GroupMember struct {
+	Project   string   `json:"project"`
+	DependsOn []string `json:"depends_on,omitempty"` // Other members of the same group
+}
+
+// groupSettle is how long a launched project must stay up before the
+// projects depending on it are started
+const groupSettle = time.Second
+
+// groupNode is a resolved group member
+type groupNode struct {
+	project Project
+	deps    []int // Indexes of the nodes this one depends on
+}
+
+// resolveGroup looks up the group's projects and orders them so every node
+// comes after its dependencies. Dependency cycles are reported with the
+// projects that form them.
+func resolveGroup(group Group, projects []Project) ([]groupNode, error) {
+	if len(group.Members) == 0 {
+		return nil, fmt.Errorf("group %q has no projects", group.Name)
+	}
+
+	// Resolve members, keyed by project ID so any reference to a project works
+	members := make([]groupNode, len(group.Members))
+	byID := make(map[string]int)
+	for i, member := range group.Members {
+		index, err := findProject(projects, member.Project)
+		if err != nil {
+			return nil, fmt.Errorf("group %q: %w", group.Name, err)
+		}
+		if _, dup := byID[projects[index].ID]; dup {
+			return nil, fmt.Errorf("group %q lists %s twice", group.Name, projects[index].Name)
+		}
+		byID[projects[index].ID] = i
+		members[i].project = projects[index]
+	}
+	for i, member := range group.Members {
+		for _, dep := range member.DependsOn {
+			index, err := findProject(projects, dep)
+			if err != nil {
+				return nil, fmt.Errorf("group %q: %s depends on %w", group.Name, members[i].project.Name, err)
+			}
+			j, ok := byID[projects[index].ID]
+			if !ok {
+				return nil, fmt.Errorf("group %q: %s depends on %s, which is not in the group", group.Name, members[i].project.Name, projects[index].Name)
+			}
+			members[i].deps = append(members[i].deps, j)
+		}
+	}
+
+	// Depth-first topological sort, keeping config order where it's free
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(members))
+	position := make([]int, len(members))
+	var order []int
+	var path []int
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			// i is on the current path, so the path from i back to i is a cycle
+			start := 0
+			for path[start] != i {
+				start++
+			}
+			var names []string
+			for _, j := range append(path[start:], i) {
+				names = append(names, members[j].project.Name)
+			}
+			return fmt.Errorf("group %q has a dependency cycle: %s", group.Name, strings.Join(names, " → "))
+		}
+		state[i] = visiting
+		path = append(path, i)
+		for _, dep := range members[i].deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		position[i] = len(order)
+		order = append(order, i)
+		return nil
+	}
+	for i := range members {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := make([]groupNode, len(order))
+	for k, i := range order {
+		nodes[k].project = members[i].project
+		for _, dep := range members[i].deps {
+			nodes[k].deps = append(nodes[k].deps, position[dep])
+		}
+	}
+	return nodes, nil
+}
+
+// dependents lists the nodes that depend on node i
+func dependents(nodes []groupNode, i int) []int {
+	var result []int
+	for j, node := range nodes {
+		for _, dep := range node.deps {
+			if dep == i {
+				result = append(result, j)
+			}
+		}
+	}
+	return result
+}
+
+// findGroup looks a group up by name, ignoring case
+func (s Settings) findGroup(name string) (Group, bool) {
+	for _, group := range s.Groups {
+		if strings.EqualFold(group.Name, name) {
+			return group, true
+		}
+	}
+	return Group{}, false
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroupNodeReadyAfterStartProcess(t *testing.T) {
+	t.Setenv("WSL_DISTRO_NAME", "Ubuntu")
+
+	tests := []struct {
+		name      string
+		project   Project
+		code      string
+		wantReady bool
+		wantErr   bool
+	}{
+		{"windows program wrapper exits cleanly", Project{ID: "w", Path: "/mnt/c/app", Command: "app.exe --serve"}, "exit 0", true, false},
+		{"windows program wrapper fails", Project{ID: "w", Path: "/mnt/c/app", Command: "app.exe"}, "exit 1", false, true},
+		{"powershell command exits", Project{ID: "p", Path: "/mnt/c/app", Command: "python main.py"}, "exit 0", false, true},
+		{"linux project exits", Project{ID: "l", Path: "/home/u/app", Command: "make run"}, "exit 0", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{processes: newProcessRegistry()}
+			launchedAt := time.Now()
+			cmd := exec.Command("sh", "-c", tt.code)
+			if err := cmd.Start(); err != nil {
+				t.Fatal(err)
+			}
+			reap := m.processes.track(processKey(tt.project), "", cmd, nil)
+			m.processes.markExited(reap().(processExitedMsg))
+
+			ready, err := m.groupNodeReady(tt.project, launchedAt)
+			if ready != tt.wantReady || (err != nil) != tt.wantErr {
+				t.Errorf("groupNodeReady = %v, %v; want ready %v, error %v", ready, err, tt.wantReady, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveGroup(t *testing.T) {
+	projects := []Project{
+		{ID: "db", Name: "DB"},
+		{ID: "api", Name: "API"},
+		{ID: "web", Name: "Web"},
+		{ID: "worker", Name: "Worker"},
+		{ID: "docs", Name: "Docs"},
+	}
+	member := func(project string, deps ...string) GroupMember {
+		return GroupMember{Project: project, DependsOn: deps}
+	}
+
+	tests := []struct {
+		name      string
+		members   []GroupMember
+		wantOrder []string         // Project names in launch order
+		wantDeps  map[string][]int // Dependency indexes into the launch order
+		wantErr   string
+	}{
+		{"config order without dependencies", []GroupMember{member("Web"), member("API"), member("DB")},
+			[]string{"Web", "API", "DB"}, nil, ""},
+		{"dependencies first", []GroupMember{member("Web", "API"), member("API", "DB"), member("DB")},
+			[]string{"DB", "API", "Web"}, map[string][]int{"Web": {1}, "API": {0}}, ""},
+		{"diamond", []GroupMember{member("Web", "API", "Worker"), member("API", "DB"), member("Worker", "DB"), member("DB")},
+			[]string{"DB", "API", "Worker", "Web"}, map[string][]int{"Web": {1, 2}, "API": {0}, "Worker": {0}}, ""},
+		{"members by ID and any case", []GroupMember{member("web", "API"), member("api")},
+			[]string{"API", "Web"}, map[string][]int{"Web": {0}}, ""},
+		{"empty group", nil, nil, nil, "has no projects"},
+		{"unknown member", []GroupMember{member("Nope")}, nil, nil, "project not found"},
+		{"duplicate member", []GroupMember{member("API"), member("api")}, nil, nil, "lists API twice"},
+		{"dependency outside the group", []GroupMember{member("API", "DB")}, nil, nil, "API depends on DB, which is not in the group"},
+		{"unknown dependency", []GroupMember{member("API", "Nope")}, nil, nil, "API depends on project not found"},
+		{"self dependency", []GroupMember{member("API", "API")}, nil, nil, "cycle: API → API"},
+		{"cycle", []GroupMember{member("Docs"), member("API", "Worker"), member("Worker", "Web"), member("Web", "API")},
+			nil, nil, "cycle: API → Worker → Web → API"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := resolveGroup(Group{Name: "dev", Members: tt.members}, projects)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var order []string
+			for _, node := range nodes {
+				order = append(order, node.project.Name)
+			}
+			if !slices.Equal(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			for _, node := range nodes {
+				if want := tt.wantDeps[node.project.Name]; !slices.Equal(node.deps, want) {
+					t.Errorf("%s deps = %v, want %v", node.project.Name, node.deps, want)
+				}
+			}
+		})
+	}
+}
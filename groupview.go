@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type nodeState int
+
+const (
+	nodePending nodeState = iota
+	nodeStarting
+	nodeReady
+	nodeFailed
+	nodeStopping
+	nodeStopped
+)
+
+func (s nodeState) String() string {
+	switch s {
+	case nodeStarting:
+		return "⏳ starting"
+	case nodeReady:
+		return "✅ ready"
+	case nodeFailed:
+		return "❌ failed"
+	case nodeStopping:
+		return "🟡 stopping"
+	case nodeStopped:
+		return "⏹️ stopped"
+	}
+	return "· waiting"
+}
+
+// groupStartTimeout is how long a launched project may take to show up as
+// running before the group launch gives up on it
+const groupStartTimeout = 5 * time.Second
+
+// groupOp is a group launch or shutdown in progress. It is shared by pointer
+// so every copy of the model advances the same operation.
+type groupOp struct {
+	name       string
+	nodes      []groupNode
+	states     []nodeState
+	launchedAt []time.Time
+	stopping   bool
+	done       bool
+	err        error
+}
+
+// groupTickMsg polls the progress of the running group operation
+type groupTickMsg struct{ op *groupOp }
+
+func (op *groupOp) tick() tea.Cmd {
+	return tea.Tick(250*time.Millisecond, func(time.Time) tea.Msg { return groupTickMsg{op} })
+}
+
+// startGroup launches the group's projects in dependency order
+func (m *model) startGroup(group Group) tea.Cmd {
+	return m.beginGroupOp(group, false)
+}
+
+// stopGroup stops the group's projects, dependents before their dependencies
+func (m *model) stopGroup(group Group) tea.Cmd {
+	return m.beginGroupOp(group, true)
+}
+
+func (m *model) beginGroupOp(group Group, stopping bool) tea.Cmd {
+	if m.groupOp != nil && !m.groupOp.done {
+		return showStatus(fmt.Sprintf("⏳ Group %s is still in progress", m.groupOp.name))
+	}
+	nodes, err := resolveGroup(group, m.projects)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ %v", err))
+	}
+	m.groupOp = &groupOp{
+		name:       group.Name,
+		nodes:      nodes,
+		states:     make([]nodeState, len(nodes)),
+		launchedAt: make([]time.Time, len(nodes)),
+		stopping:   stopping,
+	}
+	return m.advanceGroup()
+}
+
+// advanceGroup starts or stops every node whose turn has come and reports
+// when the operation is over
+func (m *model) advanceGroup() tea.Cmd {
+	op := m.groupOp
+	if op == nil || op.done {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if op.stopping {
+		cmds = m.advanceGroupStop(op)
+	} else {
+		cmds = m.advanceGroupStart(op)
+	}
+
+	if op.done {
+		switch {
+		case op.err != nil:
+			cmds = append(cmds, showStatus(fmt.Sprintf("❌ Group %s: %v", op.name, op.err)))
+		case op.stopping:
+			cmds = append(cmds, showStatus(fmt.Sprintf("⏹️ Group %s stopped", op.name)))
+		default:
+			cmds = append(cmds, showStatus(fmt.Sprintf("✅ Group %s is up", op.name)))
+		}
+		m.updateTable()
+		return tea.Batch(cmds...)
+	}
+	cmds = append(cmds, op.tick())
+	return tea.Batch(cmds...)
+}
+
+func (m *model) advanceGroupStart(op *groupOp) []tea.Cmd {
+	var cmds []tea.Cmd
+	for i, node := range op.nodes {
+		switch op.states[i] {
+		case nodePending:
+			depsReady := true
+			for _, dep := range node.deps {
+				depsReady = depsReady && op.states[dep] == nodeReady
+			}
+			if !depsReady {
+				continue
+			}
+			op.states[i] = nodeStarting
+			op.launchedAt[i] = time.Now()
+			if !m.processes.isRunning(processKey(node.project)) {
+				cmds = append(cmds, m.launchProfile(node.project, ""))
+			}
+		case nodeStarting:
+			ready, err := m.groupNodeReady(node.project, op.launchedAt[i])
+			switch {
+			case err != nil:
+				op.states[i] = nodeFailed
+				op.err = fmt.Errorf("%s %v", node.project.Name, err)
+				op.done = true
+				return cmds
+			case ready:
+				op.states[i] = nodeReady
+			}
+		}
+	}
+
+	op.done = true
+	for _, state := range op.states {
+		op.done = op.done && state == nodeReady
+	}
+	return cmds
+}
+
+// groupNodeReady reports whether a launched project is ready for its
+// dependents, or an error when it won't become ready
+func (m *model) groupNodeReady(project Project, launchedAt time.Time) (bool, error) {
+	project, err := project.withProfile("")
+	if err != nil {
+		return false, err
+	}
+	if project.Interactive || project.Terminal != "" {
+		return true, nil // Not tracked by the launcher once started
+	}
+	info, ok := m.processes.get(processKey(project))
+	if !ok || (info.StartedAt.Before(launchedAt) && info.State != procRunning) {
+		// Not (re)started yet, the launch may have failed
+		if time.Since(launchedAt) > groupStartTimeout {
+			return false, fmt.Errorf("did not start")
+		}
+		return false, nil
+	}
+	detached := project.startsDetached()
+	if info.State != procRunning && (info.ExitCode != 0 || !detached) {
+		return false, fmt.Errorf("exited with code %d before it was ready", info.ExitCode)
+	}
+	switch info.Probe {
+	case probeWaiting:
+		return false, nil
+	case probeReady:
+		return true, nil
+	case probeFailed:
+		return false, info.ProbeErr
+	}
+	if info.State != procRunning {
+		return true, nil // Start-Process returned, so the program is up
+	}
+	return time.Since(info.StartedAt) >= groupSettle, nil
+}
+
+func (m *model) advanceGroupStop(op *groupOp) []tea.Cmd {
+	var cmds []tea.Cmd
+	for i := len(op.nodes) - 1; i >= 0; i-- {
+		key := processKey(op.nodes[i].project)
+		switch op.states[i] {
+		case nodePending:
+			// Dependents go down first
+			dependentsStopped := true
+			for _, j := range dependents(op.nodes, i) {
+				dependentsStopped = dependentsStopped && op.states[j] == nodeStopped
+			}
+			if !dependentsStopped {
+				continue
+			}
+			if !m.processes.isRunning(key) {
+				op.states[i] = nodeStopped
+				continue
+			}
+			escalate, err := m.processes.stop(key, m.settings.stopGrace(), false)
+			if err != nil {
+				op.states[i] = nodeStopped
+				continue
+			}
+			op.states[i] = nodeStopping
+			cmds = append(cmds, escalate)
+		case nodeStopping:
+			if !m.processes.isRunning(key) {
+				op.states[i] = nodeStopped
+			}
+		}
+	}
+
+	op.done = true
+	for _, state := range op.states {
+		op.done = op.done && state == nodeStopped
+	}
+	return cmds
+}
+
+// groupView lists the configured groups
+type groupView struct {
+	cursor int
+}
+
+func (m *model) openGroups() tea.Cmd {
+	if m.settingsErr != nil {
+		return showStatus(fmt.Sprintf("❌ Groups unavailable, settings not loaded: %v", m.settingsErr))
+	}
+	if len(m.settings.Groups) == 0 {
+		return showStatus("🧩 No groups configured, add them under \"groups\" in settings.json")
+	}
+	m.groupMode = true
+	m.groups.cursor = min(m.groups.cursor, len(m.settings.Groups)-1)
+	return nil
+}
+
+func (m model) updateGroups(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	g := &m.groups
+	switch msg.String() {
+	case "q", "esc":
+		m.groupMode = false
+	case "up", "k":
+		g.cursor = max(g.cursor-1, 0)
+	case "down", "j":
+		g.cursor = min(g.cursor+1, len(m.settings.Groups)-1)
+	case "enter", " ":
+		return m, m.startGroup(m.settings.Groups[g.cursor])
+	case "s":
+		return m, m.stopGroup(m.settings.Groups[g.cursor])
+	}
+	return m, nil
+}
+
+func (m model) viewGroups(statusMessage string) string {
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).Render("🧩 Groups")
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	var lines []string
+	for i, group := range m.settings.Groups {
+		title := " " + group.Name + " "
+		if i == m.groups.cursor {
+			title = selectedStyle.Render(title)
+		}
+		lines = append(lines, title)
+
+		nodes, err := resolveGroup(group, m.projects)
+		if err != nil {
+			lines = append(lines, "   "+errStyle.Render(err.Error()))
+			continue
+		}
+		op := m.groupOp
+		active := op != nil && strings.EqualFold(op.name, group.Name)
+		for k, node := range nodes {
+			var deps []string
+			for _, dep := range node.deps {
+				deps = append(deps, nodes[dep].project.Name)
+			}
+			state := m.processes.statusText(processKey(node.project))
+			if active && !op.done {
+				state = op.states[k].String()
+			}
+			line := fmt.Sprintf("   %-24s %-20s", node.project.Name, state)
+			if len(deps) > 0 {
+				line += dimStyle.Render(" after " + strings.Join(deps, ", "))
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	hints := renderKeyHints([]keyHint{
+		{"↑↓", "select"},
+		{"enter", "launch group"},
+		{"s", "stop group"},
+		{"esc", "back"},
+	})
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n%s", header, strings.Join(lines, "\n"), hints, statusMessage)
+}
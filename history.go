@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const snapshotTimeFormat = "20060102-150405.000000"
+
+// snapshot is one saved copy of the config file
+type snapshot struct {
+	Path string
+	Time time.Time
+}
+
+// historyDir holds the rolling snapshots of the config file
+func (s *projectStore) historyDir() string {
+	return filepath.Join(filepath.Dir(s.path), "history")
+}
+
+// snapshotCurrent copies the config file into the history before it gets
+// replaced, skipping copies identical to the newest snapshot. Callers must
+// hold the store lock.
+func (s *projectStore) snapshotCurrent() error {
+	if s.historyLimit <= 0 || (s.session && s.sessionSaved) {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := s.Snapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 {
+		if newest, err := os.ReadFile(snapshots[0].Path); err == nil && bytes.Equal(newest, data) {
+			s.sessionSaved = s.session
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(s.historyDir(), 0755); err != nil {
+		return err
+	}
+	name := "config-" + time.Now().Format(snapshotTimeFormat) + ".json"
+	if err := writeFileAtomic(filepath.Join(s.historyDir(), name), data, 0644); err != nil {
+		return err
+	}
+	s.sessionSaved = s.session
+
+	// Drop the oldest snapshots beyond the limit
+	snapshots, err = s.Snapshots()
+	if err != nil {
+		return err
+	}
+	for _, old := range snapshots[min(len(snapshots), s.historyLimit):] {
+		os.Remove(old.Path)
+	}
+	return nil
+}
+
+// beginSession groups the following saves into one history snapshot, of the
+// config as it was before the first of them, until endSession
+func (s *projectStore) beginSession() {
+	s.session, s.sessionSaved = true, false
+}
+
+func (s *projectStore) endSession() {
+	s.session, s.sessionSaved = false, false
+}
+
+// Snapshots lists the saved snapshots, newest first
+func (s *projectStore) Snapshots() ([]snapshot, error) {
+	entries, err := os.ReadDir(s.historyDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []snapshot
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "config-") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, "config-"), ".json")
+		t, err := time.ParseInLocation(snapshotTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot{Path: filepath.Join(s.historyDir(), name), Time: t})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.After(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+// LoadSnapshot reads the projects stored in a snapshot
+func (s *projectStore) LoadSnapshot(snap snapshot) ([]Project, error) {
+	return (&projectStore{path: snap.Path}).parse()
+}
+
+type diffKind int
+
+const (
+	diffAdded   diffKind = iota // Only in the snapshot, restoring re-adds it
+	diffRemoved                 // Only in the current config
+	diffChanged
+)
+
+// projectDiff is how one project differs between a snapshot and now
+type projectDiff struct {
+	kind     diffKind
+	current  *Project
+	snapshot *Project
+	changes  []string
+}
+
+func (d projectDiff) name() string {
+	if d.snapshot != nil {
+		return d.snapshot.Name
+	}
+	return d.current.Name
+}
+
+func (d projectDiff) String() string {
+	switch d.kind {
+	case diffAdded:
+		return "+ " + d.name() + " (deleted since)"
+	case diffRemoved:
+		return "- " + d.name() + " (added since)"
+	default:
+		return "~ " + d.name() + ": " + strings.Join(d.changes, ", ")
+	}
+}
+
+// matchSnapshotProject finds the current project a snapshot entry refers to.
+// Snapshots taken before projects had IDs are matched by name and path.
+func matchSnapshotProject(current []Project, old Project) int {
+	for i := range current {
+		if old.ID != "" && current[i].ID == old.ID {
+			return i
+		}
+	}
+	if old.ID == "" {
+		for i := range current {
+			if current[i].Name == old.Name && current[i].Path == old.Path {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// diffProjects lists the projects that differ between current and snap
+func diffProjects(current, snap []Project) []projectDiff {
+	var diffs []projectDiff
+	matched := make(map[int]bool)
+	for i := range snap {
+		old := &snap[i]
+		j := matchSnapshotProject(current, *old)
+		if j == -1 {
+			diffs = append(diffs, projectDiff{kind: diffAdded, snapshot: old})
+			continue
+		}
+		matched[j] = true
+		if changes := fieldChanges(*old, current[j]); len(changes) > 0 {
+			diffs = append(diffs, projectDiff{kind: diffChanged, current: &current[j], snapshot: old, changes: changes})
+		}
+	}
+	for i := range current {
+		if !matched[i] {
+			diffs = append(diffs, projectDiff{kind: diffRemoved, current: &current[i]})
+		}
+	}
+	return diffs
+}
+
+// fieldChanges describes each config field that differs, as
+// "field: old → new" with values in their JSON form
+func fieldChanges(old, cur Project) []string {
+	a, b := projectFields(old), projectFields(cur)
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	delete(keys, "id")
+
+	var changes []string
+	for k := range keys {
+		if a[k] != b[k] {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", k, orNone(a[k]), orNone(b[k])))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// projectFields flattens a project into its JSON fields
+func projectFields(project Project) map[string]string {
+	fields := make(map[string]string)
+	data, err := json.Marshal(project)
+	if err != nil {
+		return fields
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fields
+	}
+	for k, v := range raw {
+		fields[k] = string(v)
+	}
+	return fields
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
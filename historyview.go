@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// historyView lists config snapshots and how each differs from the current
+// project list
+type historyView struct {
+	snapshots  []snapshot
+	cursor     int
+	projects   []Project // Projects stored in the selected snapshot
+	diffs      []projectDiff
+	diffCursor int
+	focusDiff  bool // Keys move through the diff instead of the snapshots
+	err        error
+}
+
+func (m *model) openHistory() tea.Cmd {
+	snapshots, err := m.store.Snapshots()
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ Failed to read history: %v", err))
+	}
+	if len(snapshots) == 0 {
+		return showStatus("🕘 No history yet, snapshots are taken whenever the config is saved")
+	}
+	m.history = historyView{snapshots: snapshots}
+	m.historyMode = true
+	m.selectSnapshot()
+	return nil
+}
+
+func (m *model) closeHistory() {
+	m.historyMode = false
+	m.history = historyView{}
+}
+
+// selectSnapshot loads the snapshot under the cursor and diffs it
+func (m *model) selectSnapshot() {
+	h := &m.history
+	h.projects, h.err = m.store.LoadSnapshot(h.snapshots[h.cursor])
+	h.diffs = diffProjects(m.projects, h.projects)
+	h.diffCursor = min(h.diffCursor, max(len(h.diffs)-1, 0))
+}
+
+// restoreSnapshot replaces the whole project list with the selected snapshot
+func (m *model) restoreSnapshot() tea.Cmd {
+	h := &m.history
+	if h.err != nil {
+		return showStatus(fmt.Sprintf("❌ Cannot restore: %v", h.err))
+	}
+	restored := make([]Project, len(h.projects))
+	copy(restored, h.projects)
+	ensureProjectIDs(restored)
+
+	previous := m.projects
+	m.projects = restored
+	if err := m.saveProjects(); err != nil {
+		m.projects = previous
+		return saveFailed(err)
+	}
+	m.updateTable()
+	when := h.snapshots[h.cursor].Time.Format("2006-01-02 15:04:05")
+	m.recordChange("restore of snapshot "+when, previous)
+	m.closeHistory()
+	return showStatus(fmt.Sprintf("♻️ Restored config from %s", when))
+}
+
+// restoreDiffEntry brings back one project as it was in the snapshot
+func (m *model) restoreDiffEntry() tea.Cmd {
+	h := &m.history
+	if len(h.diffs) == 0 {
+		return nil
+	}
+	diff := h.diffs[h.diffCursor]
+
+	previous := m.snapshotProjects()
+	switch diff.kind {
+	case diffAdded:
+		restored := *diff.snapshot
+		if restored.ID == "" || m.projectIndexByID(restored.ID) != -1 {
+			restored.ID = newProjectID()
+		}
+		m.projects = append(m.projects, restored)
+	case diffChanged:
+		index := m.projectIndexByID(diff.current.ID)
+		if index == -1 {
+			return nil
+		}
+		restored := *diff.snapshot
+		restored.ID = diff.current.ID
+		m.projects[index] = restored
+	case diffRemoved:
+		return showStatus(fmt.Sprintf("🕘 %s is not in this snapshot", diff.name()))
+	}
+
+	if err := m.saveProjects(); err != nil {
+		m.projects = previous
+		return saveFailed(err)
+	}
+	m.recordChange("restore of "+diff.name(), previous)
+	m.updateTable()
+
+	// The save took a new snapshot, so reload the list and keep the selection
+	selected := h.snapshots[h.cursor].Path
+	if snapshots, err := m.store.Snapshots(); err == nil {
+		h.snapshots = snapshots
+		h.cursor = 0
+		for i, snap := range snapshots {
+			if snap.Path == selected {
+				h.cursor = i
+			}
+		}
+	}
+	m.selectSnapshot()
+	return showStatus(fmt.Sprintf("♻️ Restored %s", diff.name()))
+}
+
+func (m model) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	h := &m.history
+	switch msg.String() {
+	case "q", "esc":
+		m.closeHistory()
+		return m, nil
+	case "tab", "left", "right":
+		h.focusDiff = !h.focusDiff && len(h.diffs) > 0
+		return m, nil
+	case "up", "k":
+		if h.focusDiff {
+			h.diffCursor = max(h.diffCursor-1, 0)
+		} else if h.cursor > 0 {
+			h.cursor--
+			m.selectSnapshot()
+		}
+		return m, nil
+	case "down", "j":
+		if h.focusDiff {
+			h.diffCursor = min(h.diffCursor+1, max(len(h.diffs)-1, 0))
+		} else if h.cursor < len(h.snapshots)-1 {
+			h.cursor++
+			m.selectSnapshot()
+		}
+		return m, nil
+	case "enter":
+		if h.focusDiff {
+			return m, m.restoreDiffEntry()
+		}
+		return m, m.restoreSnapshot()
+	}
+	return m, nil
+}
+
+// visibleWindow returns the range of a list of total items to show in height
+// lines so that cursor stays visible
+func visibleWindow(cursor, total, height int) (int, int) {
+	if total <= height {
+		return 0, total
+	}
+	start := cursor - height/2
+	start = max(0, min(start, total-height))
+	return start, start + height
+}
+
+func (m model) viewHistory(statusMessage string) string {
+	h := m.history
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).
+		Render("🕘 Config History")
+
+	listHeight := max(m.height-6, 3)
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var left []string
+	start, end := visibleWindow(h.cursor, len(h.snapshots), listHeight)
+	for i := start; i < end; i++ {
+		snap := h.snapshots[i]
+		line := fmt.Sprintf(" %s  %s ago ", snap.Time.Format("2006-01-02 15:04:05"), formatUptime(time.Since(snap.Time)))
+		if i == h.cursor {
+			if h.focusDiff {
+				line = lipgloss.NewStyle().Bold(true).Render(line)
+			} else {
+				line = selectedStyle.Render(line)
+			}
+		}
+		left = append(left, line)
+	}
+
+	var right []string
+	switch {
+	case h.err != nil:
+		right = append(right, fmt.Sprintf("Cannot read snapshot: %v", h.err))
+	case len(h.diffs) == 0:
+		right = append(right, dimStyle.Render("Same as the current config"))
+	default:
+		addedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86"))
+		removedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		changedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+		width := max(m.width-40, 20)
+		start, end := visibleWindow(h.diffCursor, len(h.diffs), listHeight)
+		for i := start; i < end; i++ {
+			diff := h.diffs[i]
+			line := runewidth.Truncate(diff.String(), width, "…")
+			switch {
+			case h.focusDiff && i == h.diffCursor:
+				line = selectedStyle.Render(line)
+			case diff.kind == diffAdded:
+				line = addedStyle.Render(line)
+			case diff.kind == diffRemoved:
+				line = removedStyle.Render(line)
+			default:
+				line = changedStyle.Render(line)
+			}
+			right = append(right, line)
+		}
+	}
+
+	leftPane := lipgloss.NewStyle().Width(36).Render(strings.Join(left, "\n"))
+	body := lipgloss.JoinHorizontal(lipgloss.Top, leftPane, strings.Join(right, "\n"))
+
+	var hints []keyHint
+	if h.focusDiff {
+		hints = []keyHint{{"↑↓", "select project"}, {"enter", "restore this project"}, {"tab", "snapshots"}, {"esc", "back"}}
+	} else {
+		hints = []keyHint{{"↑↓", "select snapshot"}, {"enter", "restore whole snapshot"}, {"tab", "projects"}, {"esc", "back"}}
+	}
+	legend := dimStyle.Render("+ only in snapshot • - only in current config • ~ changed (snapshot → current)")
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n%s\n%s", header, body, legend, renderKeyHints(hints), statusMessage)
+}
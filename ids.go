@@ -0,0 +1,33 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// newProjectID returns a random identifier for a project
+func newProjectID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// ensureProjectIDs gives every project a unique ID, replacing missing or
+// duplicated ones (e.g. from copy-pasted entries). It reports whether anything
+// changed.
+func ensureProjectIDs(projects []Project) bool {
+	changed := false
+	seen := make(map[string]bool, len(projects))
+	for i := range projects {
+		if projects[i].ID == "" || seen[projects[i].ID] {
+			projects[i].ID = newProjectID()
+			changed = true
+		}
+		seen[projects[i].ID] = true
+	}
+	return changed
+}
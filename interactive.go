@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// interactiveExitedMsg reports the end of a command run in the foreground
+type interactiveExitedMsg struct {
+	name     string
+	exitCode int
+	err      error // Set when the command could not be started
+	duration time.Duration
+}
+
+// runInteractive suspends the launcher and runs project's command in the
+// terminal, for REPLs and commands that prompt. The launcher comes back once
+// the command exits.
+func (m model) runInteractive(project Project) tea.Cmd {
+	cmd, _, err := buildLaunchCommand(project)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ Failed to launch %s: %v", project.Name, err))
+	}
+	// The command must share the launcher's process group, which owns the
+	// terminal, or it is stopped as soon as it reads from it
+	cmd.SysProcAttr = nil
+
+	name := project.Name
+	if project.profile != "" {
+		name += " (" + project.profile + ")"
+	}
+	started := time.Now()
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		msg := interactiveExitedMsg{name: name, duration: time.Since(started)}
+		if err != nil && cmd.ProcessState == nil {
+			msg.err = err
+			return msg
+		}
+		msg.exitCode, _ = exitStatus(err)
+		return msg
+	})
+}
+
+func (msg interactiveExitedMsg) status() string {
+	switch {
+	case msg.err != nil:
+		return fmt.Sprintf("❌ Failed to launch %s: %v", msg.name, msg.err)
+	case msg.exitCode != 0:
+		return fmt.Sprintf("❌ %s exited with code %d after %s", msg.name, msg.exitCode, formatUptime(msg.duration))
+	}
+	return fmt.Sprintf("✅ %s finished after %s", msg.name, formatUptime(msg.duration))
+}
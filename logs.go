@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const logTimeFormat = "2006-01-02 15:04:05.000"
+
+// launchMarker starts the line written at the top of every launch in a log
+const launchMarker = "=== Launch"
+
+// launchLog is a size-rotated log file shared by the stdout and stderr of one
+// launch. Every line is prefixed with a timestamp and the stream it came from.
+type launchLog struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	size     int64
+	maxBytes int64
+	keep     int
+	streams  []*streamWriter
+}
+
+// streamWriter splits a process stream into lines for a launchLog
+type streamWriter struct {
+	log    *launchLog
+	stream string
+	buf    []byte
+}
+
+// logPathFor resolves where a project's output is written. A per-project
+// log_file wins (relative paths are taken from the project directory), then
+// the global log_dir, then the default state directory.
+func logPathFor(project Project, settings Settings) string {
+	if project.LogFile != "" {
+		path := expandHome(project.LogFile)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(project.Path, path)
+		}
+		return path
+	}
+
+	dir := expandHome(settings.LogDir)
+	if dir == "" {
+		dir = filepath.Join(stateDir(), "logs")
+	}
+	path := filepath.Join(dir, logFileName(project))
+	if project.ID == "" {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	// A renamed project keeps writing to the log named after its old name
+	suffix := "-" + safeFileName(project.ID) + ".log"
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), suffix) {
+				return filepath.Join(dir, entry.Name())
+			}
+		}
+	}
+	return path
+}
+
+// stateDir follows the XDG base directory spec for state data
+func stateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "project-launcher")
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "project-launcher")
+	}
+	return filepath.Join(homeDir, ".local", "state", "project-launcher")
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, path[1:])
+		}
+	}
+	return path
+}
+
+// logFileName is the default log file name of a project. The project ID
+// keeps projects with the same name apart.
+func logFileName(project Project) string {
+	name := safeFileName(strings.TrimSpace(project.Name))
+	if name == "" {
+		name = "project"
+	}
+	if project.ID != "" {
+		name += "-" + safeFileName(project.ID)
+	}
+	return name + ".log"
+}
+
+// safeFileName replaces the characters file systems reject
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// openLaunchLog opens path for appending, rotating it first if it is already
+// over maxBytes
+func openLaunchLog(path string, maxBytes int64, keep int) (*launchLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	l := &launchLog{path: path, maxBytes: maxBytes, keep: keep}
+	if info, err := os.Stat(path); err == nil && info.Size() >= maxBytes {
+		if err := rotateLogFiles(path, keep); err != nil {
+			return nil, err
+		}
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *launchLog) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotateLogFiles shifts path.N to path.N+1, dropping anything past keep
+func rotateLogFiles(path string, keep int) error {
+	if keep <= 0 {
+		return os.Remove(path)
+	}
+	os.Remove(fmt.Sprintf("%s.%d", path, keep))
+	for i := keep - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	return os.Rename(path, path+".1")
+}
+
+// writeLine appends one tagged line, rotating the file when it grows too big
+func (l *launchLog) writeLine(stream, text string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return
+	}
+	line := fmt.Sprintf("%s [%s] %s\n", time.Now().Format(logTimeFormat), stream, text)
+	if l.size > 0 && l.size+int64(len(line)) > l.maxBytes {
+		l.file.Close()
+		l.file = nil
+		if err := rotateLogFiles(l.path, l.keep); err != nil {
+			return
+		}
+		if err := l.open(); err != nil {
+			return
+		}
+	}
+	n, _ := l.file.WriteString(line)
+	l.size += int64(n)
+}
+
+// writeLaunch records the start of a launch
+func (l *launchLog) writeLaunch(command string) {
+	l.writeLine("launcher", fmt.Sprintf("%s: %s ===", launchMarker, command))
+}
+
+// finish records how a launch ended and closes the log. It must only be called
+// after the process streams have been fully copied, i.e. after cmd.Wait.
+func (l *launchLog) finish(msg processExitedMsg) error {
+	l.mu.Lock()
+	streams := l.streams
+	l.mu.Unlock()
+	for _, w := range streams {
+		w.flush()
+	}
+
+	if msg.signaled {
+		l.writeLine("launcher", fmt.Sprintf("=== Terminated (code %d) ===", msg.exitCode))
+	} else {
+		l.writeLine("launcher", fmt.Sprintf("=== Exited with code %d ===", msg.exitCode))
+	}
+	return l.Close()
+}
+
+// streamWriter returns an io.Writer that tags every line with stream
+func (l *launchLog) streamWriter(stream string) *streamWriter {
+	w := &streamWriter{log: l, stream: stream}
+	l.mu.Lock()
+	l.streams = append(l.streams, w)
+	l.mu.Unlock()
+	return w
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.log.writeLine(w.stream, strings.TrimSuffix(string(w.buf[:i]), "\r"))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *streamWriter) flush() {
+	if len(w.buf) > 0 {
+		w.log.writeLine(w.stream, string(w.buf))
+		w.buf = nil
+	}
+}
+
+func (l *launchLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// launchTail returns up to n output lines of the latest launch in a log,
+// without their timestamps. With streams given, only lines from those streams
+// are kept; launcher lines are always left out.
+func launchTail(path string, n int, streams ...string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	// The tail is all that's needed; a launch that printed more than this
+	// has its marker cut off, which is fine
+	const window = 64 << 10
+	if info, err := file.Stat(); err == nil && info.Size() > window {
+		file.Seek(info.Size()-window, io.SeekStart)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil
+	}
+	if marker := bytes.LastIndex(data, []byte(launchMarker)); marker != -1 {
+		data = data[marker:]
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		stream, text, ok := parseLogLine(line)
+		if !ok || stream == "launcher" || (len(streams) > 0 && !slices.Contains(streams, stream)) {
+			continue
+		}
+		lines = append(lines, text)
+	}
+	return lines[max(len(lines)-n, 0):]
+}
+
+// parseLogLine splits a line written by writeLine into its stream and text
+func parseLogLine(line string) (stream, text string, ok bool) {
+	if len(line) <= len(logTimeFormat)+1 {
+		return "", "", false
+	}
+	rest := line[len(logTimeFormat)+1:]
+	end := strings.Index(rest, "] ")
+	if !strings.HasPrefix(rest, "[") || end == -1 {
+		return "", "", false
+	}
+	return rest[1:end], ansi.Strip(rest[end+2:]), true
+}
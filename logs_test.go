@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogPathForKeepsProjectsApart(t *testing.T) {
+	dir := t.TempDir()
+	settings := Settings{LogDir: dir}
+
+	first := Project{ID: "aaaa", Name: "New Project"}
+	second := Project{ID: "bbbb", Name: "New Project"}
+	if logPathFor(first, settings) == logPathFor(second, settings) {
+		t.Fatalf("projects with the same name share %s", logPathFor(first, settings))
+	}
+	if got, want := logPathFor(first, settings), filepath.Join(dir, "New Project-aaaa.log"); got != want {
+		t.Errorf("logPathFor = %s, want %s", got, want)
+	}
+
+	// A renamed project keeps its existing log
+	if err := os.WriteFile(logPathFor(first, settings), []byte("history\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	renamed := first
+	renamed.Name = "API"
+	if got, want := logPathFor(renamed, settings), filepath.Join(dir, "New Project-aaaa.log"); got != want {
+		t.Errorf("renamed project logs to %s, want %s", got, want)
+	}
+
+	// log_file still wins
+	custom := Project{ID: "cccc", Name: "Web", Path: dir, LogFile: "out.log"}
+	if got, want := logPathFor(custom, settings), filepath.Join(dir, "out.log"); got != want {
+		t.Errorf("logPathFor with log_file = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logViewer is the full-screen pane that tails a project's log file
+type logViewer struct {
+	projectName string
+	path        string
+	viewport    viewport.Model
+	lines       []string // Raw lines, ANSI colors intact
+	plain       []string // Lines with escape codes stripped, used for searching
+	size        int64
+	modTime     time.Time
+	follow      bool // Keep the view pinned to the end of the file
+	err         error
+	searching   bool
+	search      textinput.Model
+	query       string
+	matches     []int // Line numbers matching query
+	matchIndex  int
+}
+
+var (
+	matchStyle        = lipgloss.NewStyle().Reverse(true)
+	currentMatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("220"))
+)
+
+// openLogs shows the log of the project under the cursor
+func (m *model) openLogs(project Project) {
+	path := logPathFor(project, m.settings)
+	if info, ok := m.processes.get(processKey(project)); ok && info.LogPath != "" {
+		path = info.LogPath
+	}
+
+	search := textinput.New()
+	search.Prompt = "/"
+	search.CharLimit = 200
+
+	m.logs = logViewer{
+		projectName: project.Name,
+		path:        path,
+		viewport:    viewport.New(m.width, m.logViewHeight()),
+		follow:      true,
+		search:      search,
+	}
+	m.logMode = true
+	m.logs.reload(true)
+}
+
+func (m *model) closeLogs() {
+	m.logMode = false
+	m.logs = logViewer{}
+}
+
+func (m *model) logViewHeight() int {
+	height := m.height - 5
+	if height < 3 {
+		height = 3
+	}
+	return height
+}
+
+// reload re-reads the log file if it changed since the last read
+func (v *logViewer) reload(force bool) {
+	info, err := os.Stat(v.path)
+	if err != nil {
+		v.err = err
+		v.lines, v.plain = nil, nil
+		v.render()
+		return
+	}
+	if !force && info.Size() == v.size && info.ModTime().Equal(v.modTime) {
+		return
+	}
+
+	data, err := os.ReadFile(v.path)
+	if err != nil {
+		v.err = err
+		return
+	}
+	v.err = nil
+	v.size = info.Size()
+	v.modTime = info.ModTime()
+	v.lines = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	v.plain = make([]string, len(v.lines))
+	for i, line := range v.lines {
+		v.plain[i] = ansi.Strip(line)
+	}
+	v.findMatches()
+	v.render()
+}
+
+// render pushes the lines into the viewport, highlighting search matches
+func (v *logViewer) render() {
+	if v.err != nil {
+		v.viewport.SetContent(fmt.Sprintf("Cannot read %s: %v", v.path, v.err))
+		return
+	}
+
+	current := -1
+	if len(v.matches) > 0 {
+		current = v.matches[v.matchIndex]
+	}
+
+	rendered := make([]string, len(v.lines))
+	copy(rendered, v.lines)
+	for _, lineNum := range v.matches {
+		style := matchStyle
+		if lineNum == current {
+			style = currentMatchStyle
+		}
+		rendered[lineNum] = highlightMatches(v.plain[lineNum], v.query, style)
+	}
+
+	v.viewport.SetContent(strings.Join(rendered, "\n"))
+	if v.follow {
+		v.viewport.GotoBottom()
+	}
+}
+
+// highlightMatches styles every case-insensitive occurrence of query in line
+func highlightMatches(line, query string, style lipgloss.Style) string {
+	lowerLine := strings.ToLower(line)
+	lowerQuery := strings.ToLower(query)
+	if query == "" || len(lowerLine) != len(line) {
+		return style.Render(line)
+	}
+
+	var b strings.Builder
+	rest := 0
+	for {
+		i := strings.Index(lowerLine[rest:], lowerQuery)
+		if i < 0 {
+			break
+		}
+		start := rest + i
+		end := start + len(lowerQuery)
+		b.WriteString(line[rest:start])
+		b.WriteString(style.Render(line[start:end]))
+		rest = end
+	}
+	b.WriteString(line[rest:])
+	return b.String()
+}
+
+func (v *logViewer) findMatches() {
+	v.matches = nil
+	if v.query == "" {
+		return
+	}
+	query := strings.ToLower(v.query)
+	for i, line := range v.plain {
+		if strings.Contains(strings.ToLower(line), query) {
+			v.matches = append(v.matches, i)
+		}
+	}
+	if v.matchIndex >= len(v.matches) {
+		v.matchIndex = len(v.matches) - 1
+	}
+	if v.matchIndex < 0 {
+		v.matchIndex = 0
+	}
+}
+
+// scrollTo centers line in the viewport and stops following
+func (v *logViewer) scrollTo(line int) {
+	v.follow = false
+	offset := line - v.viewport.Height/2
+	if offset < 0 {
+		offset = 0
+	}
+	v.viewport.SetYOffset(offset)
+}
+
+// jumpMatch moves to the next (dir 1) or previous (dir -1) search match
+func (v *logViewer) jumpMatch(dir int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.matchIndex = (v.matchIndex + dir + len(v.matches)) % len(v.matches)
+	v.render()
+	v.scrollTo(v.matches[v.matchIndex])
+}
+
+// jumpLaunch moves to the next (dir 1) or previous (dir -1) launch marker
+// relative to the top of the viewport
+func (v *logViewer) jumpLaunch(dir int) {
+	top := v.viewport.YOffset
+	for i := top + dir; i >= 0 && i < len(v.plain); i += dir {
+		if strings.Contains(v.plain[i], launchMarker) {
+			v.follow = false
+			v.viewport.SetYOffset(i)
+			return
+		}
+	}
+}
+
+// scrollToLastLaunch shows the latest launch from its marker on
+func (v *logViewer) scrollToLastLaunch() {
+	for i := len(v.plain) - 1; i >= 0; i-- {
+		if strings.Contains(v.plain[i], launchMarker) {
+			v.follow = false
+			v.viewport.SetYOffset(i)
+			return
+		}
+	}
+}
+
+func (m model) updateLogView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.logs
+
+	if v.searching {
+		switch msg.String() {
+		case "enter":
+			v.searching = false
+			v.search.Blur()
+			v.query = v.search.Value()
+			v.matchIndex = 0
+			v.findMatches()
+			v.render()
+			if len(v.matches) == 0 {
+				if v.query != "" {
+					return m, showStatus(fmt.Sprintf("🔍 No matches for %q", v.query))
+				}
+				return m, nil
+			}
+			// Start from the match closest to the end, where new output lands
+			v.matchIndex = len(v.matches) - 1
+			v.render()
+			v.scrollTo(v.matches[v.matchIndex])
+			return m, nil
+		case "esc":
+			v.searching = false
+			v.search.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		v.search, cmd = v.search.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		m.closeLogs()
+		return m, nil
+	case "/":
+		v.searching = true
+		v.search.SetValue(v.query)
+		v.search.CursorEnd()
+		return m, v.search.Focus()
+	case "n":
+		v.jumpMatch(1)
+		return m, nil
+	case "N":
+		v.jumpMatch(-1)
+		return m, nil
+	case "[":
+		v.jumpLaunch(-1)
+		return m, nil
+	case "]":
+		v.jumpLaunch(1)
+		return m, nil
+	case "f":
+		v.follow = !v.follow
+		if v.follow {
+			v.viewport.GotoBottom()
+		}
+		return m, nil
+	case "G", "end":
+		v.follow = true
+		v.viewport.GotoBottom()
+		return m, nil
+	case "g", "home":
+		v.follow = false
+		v.viewport.GotoTop()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	// Scrolling away from the end pauses following, scrolling back resumes it
+	v.follow = v.viewport.AtBottom()
+	return m, cmd
+}
+
+func (m model) viewLogs(statusMessage string) string {
+	v := m.logs
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).
+		Render("📜 " + v.projectName)
+	state := "paused"
+	if v.follow {
+		state = "following"
+	}
+	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	header += " " + pathStyle.Render(fmt.Sprintf("%s • %s", v.path, state))
+
+	var footer string
+	if v.searching {
+		footer = v.search.View()
+	} else {
+		hints := []keyHint{
+			{"↑↓/pgup/pgdn", "scroll"},
+			{"/", "search"},
+		}
+		if v.query != "" {
+			hints = append(hints, keyHint{"n/N", fmt.Sprintf("match %d/%d", min(v.matchIndex+1, len(v.matches)), len(v.matches))})
+		}
+		hints = append(hints,
+			keyHint{"[/]", "prev/next launch"},
+			keyHint{"f", "follow"},
+			keyHint{"g/G", "top/bottom"},
+			keyHint{"esc", "back"},
+		)
+		footer = renderKeyHints(hints)
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s\n%s", header, v.viewport.View(), footer, statusMessage)
+}
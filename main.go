@@ -1,12 +1,13 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"syscall"
@@ -19,11 +20,35 @@ import (
 )
 
 type Project struct {
+	ID       string `json:"id"`
 	Name     string `json:"name"`
 	Path     string `json:"path"`
 	Command  string `json:"command"`
 	Link     string `json:"link"`
 	Category string `json:"category"`
+	LogFile  string `json:"log_file,omitempty"`
+	Tasks    []Task `json:"tasks,omitempty"`
+
+	Env          map[string]string `json:"env,omitempty"`
+	EnvFiles     []string          `json:"env_files,omitempty"`     // Dotenv files, relative to Path
+	CleanEnv     bool              `json:"clean_env,omitempty"`     // Don't inherit the launcher's environment
+	EnvAllowlist []string          `json:"env_allowlist,omitempty"` // Variables kept with clean_env
+
+	Interactive bool           `json:"interactive,omitempty"` // Run in the foreground, suspending the launcher
+	Editor      string         `json:"editor,omitempty"`      // Editor command for this project, see editorCommand
+	Terminal    string         `json:"terminal,omitempty"`    // Launch into tmux, wt or terminal instead of the background
+	Platform    string         `json:"platform,omitempty"`    // windows, linux or auto (the default), see runsOnWindows
+	Windows     *WindowsLaunch `json:"windows,omitempty"`     // Structured launch for Windows programs
+
+	Ready   *ReadyProbe    `json:"ready,omitempty"`
+	Restart *RestartPolicy `json:"restart,omitempty"`
+
+	Profiles       []Profile `json:"profiles,omitempty"`
+	DefaultProfile string    `json:"default_profile,omitempty"`
+
+	// Set by withProfile on the copy being launched, never saved
+	profile    string
+	profileEnv map[string]string
 }
 
 type statusMsg struct {
@@ -37,23 +62,51 @@ func showStatus(msg string) tea.Cmd {
 }
 
 type model struct {
-	projects       []Project
-	table          table.Model
-	editMode       bool
-	editRow        int
-	editCol        int
-	textInput      textinput.Model
-	configFile     string
-	width          int
-	height         int
-	statusMsg      string
-	statusExpiry   time.Time
-	scrollOffset   int            // For horizontal scrolling
-	maxCols        int            // Maximum visible columns
-	projectIndices []int          // Maps display row to actual project index (-1 for headers)
-	allColumns     []table.Column // Store all possible columns
-	confirmDelete  bool           // Confirmation mode for deletion
-	deleteIndex    int            // Index of project to delete
+	projects      []Project
+	table         table.Model
+	editMode      bool
+	editRow       int
+	editCol       int
+	textInput     textinput.Model
+	configFile    string
+	store         *projectStore
+	loadErr       error // Why the config could not be loaded, shown until fixed
+	settingsErr   error // Why settings.json could not be loaded; defaults are used meanwhile
+	width         int
+	height        int
+	statusMsg     string
+	statusExpiry  time.Time
+	scrollOffset  int              // For horizontal scrolling
+	maxCols       int              // Maximum visible columns
+	rowProjectIDs []string         // Maps display row to project ID ("" for headers)
+	allColumns    []table.Column   // Store all possible columns
+	confirmDelete bool             // Confirmation mode for deletion
+	deleteID      string           // ID of project to delete
+	processes     *processRegistry // Launched processes, keyed by processKey
+	settings      Settings
+	logMode       bool      // Log viewer is open
+	logs          logViewer // Log viewer state while logMode is set
+	filterMode    bool      // Filter input has focus
+	filterInput   textinput.Model
+	filter        string              // Applied fuzzy filter, empty shows every project
+	filterTop     int                 // Display index of the best filter match (-1 for none)
+	historyMode   bool                // Config history view is open
+	history       historyView         // History view state while historyMode is set
+	undo          undoStack           // Changes that u and ctrl+r can undo and redo this session
+	discoverMode  bool                // Discovery checklist is open
+	suggestions   []commandSuggestion // Detected commands for the Command field being edited
+	taskMode      bool                // Task menu is open
+	tasks         taskView            // Task menu state while taskMode is set
+	profileMode   bool                // Profile picker is open
+	profiles      profilePicker       // Profile picker state while profileMode is set
+	envMode       bool                // Environment preview is open
+	envView       envPreview          // Environment preview state while envMode is set
+	groupMode     bool                // Group list is open
+	groups        groupView           // Group list state while groupMode is set
+	groupOp       *groupOp            // Group launch or shutdown in progress, if any
+	suggestion    int                 // Index of the suggestion in the input, -1 when typed by hand
+	discovery     discoveryView       // Discovery state while discoverMode is set
+	failure       *launchFailure      // Launch that exited early, shown until dismissed
 }
 
 func main() {
@@ -63,8 +116,11 @@ func main() {
 	}
 	configFile := filepath.Join(homeDir, ".config", "project-launcher", "config.json")
 
+	if len(os.Args) > 1 {
+		os.Exit(runCLI(os.Args[1:], configFile))
+	}
+
 	m := model{
-		projects:      loadProjects(configFile),
 		configFile:    configFile,
 		width:         100,
 		height:        24,
@@ -72,14 +128,18 @@ func main() {
 		editRow:       -1,
 		editCol:       -1,
 		scrollOffset:  0,
-		maxCols:       5, // Updated to 5 columns (Name, Path, Command, Link, Category)
+		maxCols:       6, // Name, Status, Path, Command, Category, Link
 		confirmDelete: false,
-		deleteIndex:   -1,
+		filterTop:     -1,
+		processes:     newProcessRegistry(),
 	}
+	m.settings, m.settingsErr = loadSettings(settingsFileFor(configFile))
+	m.store = newProjectStore(configFile, m.settings.historyCount())
 
 	// Define all possible columns
 	m.allColumns = []table.Column{
 		{Title: "Name", Width: 30},
+		{Title: "Status", Width: 20},
 		{Title: "Path", Width: 35},
 		{Title: "Command", Width: 35},
 		{Title: "Category", Width: 15},
@@ -90,6 +150,11 @@ func main() {
 	m.textInput = textinput.New()
 	m.textInput.CharLimit = 200
 
+	// Initialize text input for the fuzzy filter
+	m.filterInput = textinput.New()
+	m.filterInput.Prompt = "/"
+	m.filterInput.CharLimit = 100
+
 	// Initialize table with initial columns
 	t := table.New(
 		table.WithColumns(m.allColumns[:4]), // Start with first 4 columns
@@ -110,6 +175,7 @@ func main() {
 	t.SetStyles(s)
 
 	m.table = t
+	m.loadProjects()
 	m.adjustLayout()
 	m.updateTable()
 
@@ -119,22 +185,28 @@ func main() {
 	}
 }
 
-func loadProjects(configFile string) []Project {
-	var projects []Project
-	data, err := os.ReadFile(configFile)
-	if err != nil {
-		return projects
+// loadProjects (re)reads the project list, remembering any error so the UI
+// can show it and saving stays disabled until the file is fixed
+func (m *model) loadProjects() {
+	projects, err := m.store.Load()
+	m.loadErr = err
+	var cfgErr *configError
+	if errors.As(err, &cfgErr) {
+		m.projects = nil
+		return
 	}
-	json.Unmarshal(data, &projects)
-	return projects
+	m.projects = projects
 }
 
-func (m *model) saveProjects() {
-	data, err := json.MarshalIndent(m.projects, "", "  ")
-	if err != nil {
-		return
+func (m *model) saveProjects() error {
+	if m.loadErr != nil {
+		return fmt.Errorf("config failed to load: %w", m.loadErr)
 	}
-	os.WriteFile(m.configFile, data, 0644)
+	return m.store.Save(m.projects)
+}
+
+func saveFailed(err error) tea.Cmd {
+	return showStatus(fmt.Sprintf("❌ Failed to save: %v", err))
 }
 
 func (m *model) updateTable() {
@@ -142,12 +214,28 @@ func (m *model) updateTable() {
 	visibleColumns := m.table.Columns()
 
 	var rows []table.Row
-	m.projectIndices = []int{} // Reset project indices mapping
+	m.rowProjectIDs = []string{} // Reset row to project mapping
 
 	var lastCategory string
-	projectIndex := 0
 
-	for _, project := range sortedProjects {
+	// With a filter applied only matching projects are shown, in their usual order
+	var matches map[int]projectMatch
+	topHit := -1
+	if m.filter != "" {
+		var ranked []int
+		ranked, matches = filteredProjects(m.filter, sortedProjects)
+		if len(ranked) > 0 {
+			topHit = ranked[0]
+		}
+	}
+	m.filterTop = -1
+
+	for projectIndex, project := range sortedProjects {
+		match, matched := matches[projectIndex]
+		if m.filter != "" && !matched {
+			continue
+		}
+
 		// Handle empty category display
 		displayCategory := project.Category
 		if displayCategory == "" {
@@ -167,12 +255,13 @@ func (m *model) updateTable() {
 			}
 
 			rows = append(rows, headerRow)
-			m.projectIndices = append(m.projectIndices, -1) // -1 indicates header row
+			m.rowProjectIDs = append(m.rowProjectIDs, "") // "" indicates header row
 			lastCategory = displayCategory
 		}
 
 		// Create project row - build full row data first
-		fullRowData := []string{project.Name, project.Path, project.Command, displayCategory, project.Link}
+		status := m.processes.statusText(processKey(project))
+		fullRowData := []string{project.Name, project.Path, project.commandCell(), displayCategory, project.Link, status}
 
 		// Create visible row based on current visible columns and scroll offset
 		visibleRow := make(table.Row, len(visibleColumns))
@@ -180,14 +269,19 @@ func (m *model) updateTable() {
 			columnIndex := m.getColumnIndex(col.Title)
 			if columnIndex >= 0 && columnIndex < len(fullRowData) {
 				visibleRow[i] = fullRowData[columnIndex]
+				if indexes, ok := match.fields[columnIndex]; ok {
+					visibleRow[i] = highlightCell(fullRowData[columnIndex], indexes, col.Width)
+				}
 			} else {
 				visibleRow[i] = ""
 			}
 		}
 
+		if projectIndex == topHit {
+			m.filterTop = len(rows)
+		}
 		rows = append(rows, visibleRow)
-		m.projectIndices = append(m.projectIndices, projectIndex)
-		projectIndex++
+		m.rowProjectIDs = append(m.rowProjectIDs, project.ID)
 	}
 	m.table.SetRows(rows)
 }
@@ -204,6 +298,8 @@ func (m *model) getColumnIndex(title string) int {
 		return 3
 	case "Link":
 		return 4
+	case "Status":
+		return 5
 	default:
 		return -1
 	}
@@ -283,6 +379,7 @@ func (m *model) startEdit() {
 	}
 
 	m.editMode = true
+	m.store.beginSession() // Tabbing through the fields takes a single snapshot
 	displayIndex := m.table.Cursor()
 	m.editRow = m.getOriginalIndexByDisplayIndex(displayIndex)
 	if m.editRow == -1 {
@@ -299,7 +396,7 @@ func (m *model) startEdit() {
 	case 1:
 		initialValue = project.Path
 	case 2:
-		initialValue = project.Command
+		initialValue = project.activeCommand()
 	case 3:
 		initialValue = project.Link
 	case 4:
@@ -310,11 +407,12 @@ func (m *model) startEdit() {
 	m.textInput.Focus()
 }
 
-func (m *model) saveEdit() {
+func (m *model) saveEdit() error {
 	if !m.editMode || m.editRow < 0 || m.editRow >= len(m.projects) {
-		return
+		return nil
 	}
 
+	before := m.snapshotProjects()
 	value := m.textInput.Value()
 	switch m.editCol {
 	case 0:
@@ -322,27 +420,76 @@ func (m *model) saveEdit() {
 	case 1:
 		m.projects[m.editRow].Path = value
 	case 2:
-		m.projects[m.editRow].Command = value
+		m.projects[m.editRow].setActiveCommand(value)
 	case 3:
 		m.projects[m.editRow].Link = value
 	case 4:
 		m.projects[m.editRow].Category = value
 	}
 
-	m.saveProjects()
+	if reflect.DeepEqual(m.projects[m.editRow], before[m.editRow]) {
+		return nil // Tabbed past without a change
+	}
+
+	if err := m.saveProjects(); err != nil {
+		m.projects = before
+		m.updateTable()
+		return err
+	}
 	m.updateTable()
+	field := strings.ToLower(editFieldNames[m.editCol])
+	m.recordChange(fmt.Sprintf("edit %s of %s", field, before[m.editRow].Name), before)
+	return nil
 }
 
+// editFieldNames are the fields cycled through by tab in edit mode, by editCol
+var editFieldNames = []string{"Name", "Path", "Command", "Link", "Category"}
+
 func (m *model) cancelEdit() {
 	m.editMode = false
+	m.store.endSession()
 	m.editRow = -1
 	m.editCol = -1
 	m.textInput.Blur()
 	m.textInput.SetValue("")
+	m.suggestions = nil
+}
+
+// loadSuggestions detects launch commands for the project being edited when
+// the Command field is selected. A placeholder command is replaced by the
+// best suggestion.
+func (m *model) loadSuggestions() {
+	m.suggestions = nil
+	m.suggestion = -1
+	if m.editCol != 2 {
+		return
+	}
+	project := m.projects[m.editRow]
+	m.suggestions = detectCommands(project.Path)
+	for i, s := range m.suggestions {
+		if s.Command == m.textInput.Value() {
+			m.suggestion = i
+		}
+	}
+	if value := m.textInput.Value(); len(m.suggestions) > 0 && (value == "" || value == "command") {
+		m.cycleSuggestion(1)
+	}
+}
+
+// cycleSuggestion puts the next or previous suggestion into the input
+func (m *model) cycleSuggestion(step int) {
+	n := len(m.suggestions)
+	if m.suggestion == -1 && step < 0 {
+		m.suggestion = 0
+	}
+	m.suggestion = ((m.suggestion+step)%n + n) % n
+	value := m.suggestions[m.suggestion].Command
+	m.textInput.SetValue(value)
+	m.textInput.SetCursor(len(value))
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.SetWindowTitle("Project Launcher")
+	return tea.Batch(tea.SetWindowTitle("Project Launcher"), tickEvery())
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -354,14 +501,150 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusExpiry = time.Now().Add(3 * time.Second)
 		return m, nil
 
+	case processExitedMsg:
+		if record, ok := readRunRecord(msg.key); ok && record.PID == msg.pid {
+			msg.stopped = record.Stopping
+		}
+		removeRunRecord(msg.key, msg.pid)
+		restart := m.processes.markExited(msg)
+		m.updateTable()
+		if failure := m.earlyExit(msg); failure != nil {
+			// Replaces the launch status, which claimed success
+			m.failure = failure
+			m.statusMsg = ""
+		}
+		for _, project := range m.projects {
+			if processKey(project) != msg.key {
+				continue
+			}
+			if restart {
+				info, _ := m.processes.get(msg.key)
+				return m, m.launchProfile(project, info.Profile)
+			}
+			return m, m.scheduleRestart(project, msg)
+		}
+		return m, nil
+
+	case interactiveExitedMsg:
+		return m, showStatus(msg.status())
+
+	case editorExitedMsg:
+		if msg.err != nil {
+			return m, showStatus(fmt.Sprintf("❌ Editor for %s failed: %v", msg.name, msg.err))
+		}
+		return m, nil
+
+	case shellExitedMsg:
+		if msg.err != nil {
+			return m, showStatus(fmt.Sprintf("❌ Failed to open a shell: %v", msg.err))
+		}
+		return m, showStatus(fmt.Sprintf("🐚 Back from %s", msg.name))
+
+	case restartDueMsg:
+		if !m.processes.takeRestart(msg) {
+			return m, nil // Relaunched or stopped while waiting
+		}
+		for _, project := range m.projects {
+			if processKey(project) == msg.key {
+				info, _ := m.processes.get(msg.key)
+				return m, m.launchProfile(project, info.Profile)
+			}
+		}
+		return m, nil
+
+	case probeResultMsg:
+		m.processes.markProbed(msg)
+		m.updateTable()
+		switch {
+		case msg.err == nil:
+			return m, showStatus(fmt.Sprintf("✅ %s is ready", msg.name))
+		case !errors.Is(msg.err, errProbeExited):
+			return m, showStatus(fmt.Sprintf("❌ %s %v", msg.name, msg.err))
+		}
+		return m, nil
+
+	case groupTickMsg:
+		if msg.op != m.groupOp {
+			return m, nil // Superseded by a newer operation
+		}
+		return m, m.advanceGroup()
+
+	case taskFinishedMsg:
+		return m, m.finishTask(msg)
+
+	case discoveryResultMsg:
+		// Results of a closed scan, or of one before the current, are stale
+		if m.discoverMode && msg.scan == m.discovery.scan {
+			m.discovery.scanning = false
+			m.discovery.candidates = msg.candidates
+			m.discovery.err = msg.err
+		}
+		return m, nil
+
+	case stopEscalateMsg:
+		if m.processes.escalate(msg) {
+			return m, showStatus("💀 Grace period expired, sent SIGKILL")
+		}
+		return m, nil
+
+	case tickMsg:
+		// Refresh uptimes while anything is running
+		if m.processes.anyRunning() {
+			m.updateTable()
+		}
+		if m.logMode {
+			m.logs.reload(false)
+		}
+		if m.taskMode {
+			m.refreshTaskOutput()
+		}
+		return m, tickEvery()
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.adjustLayout()
 		m.updateTable()
+		if m.logMode {
+			m.logs.viewport.Width = m.width
+			m.logs.viewport.Height = m.logViewHeight()
+			m.logs.render()
+		}
+		if m.taskMode {
+			m.tasks.output.Width = m.width
+			m.tasks.output.Height = m.taskOutputHeight()
+		}
+		if m.envMode {
+			m.envView.viewport.Width = m.width
+			m.envView.viewport.Height = max(m.height-5, 3)
+		}
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.logMode {
+			return m.updateLogView(msg)
+		}
+		if m.historyMode {
+			return m.updateHistory(msg)
+		}
+		if m.discoverMode {
+			return m.updateDiscovery(msg)
+		}
+		if m.taskMode {
+			return m.updateTasks(msg)
+		}
+		if m.profileMode {
+			return m.updateProfilePicker(msg)
+		}
+		if m.envMode {
+			return m.updateEnvPreview(msg)
+		}
+		if m.groupMode {
+			return m.updateGroups(msg)
+		}
+		if m.filterMode {
+			return m.updateFilter(msg)
+		}
 		if m.editMode {
 			return m.updateEdit(msg)
 		}
@@ -371,6 +654,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateNormal(msg)
 	}
 
+	if m.logMode {
+		m.logs.viewport, cmd = m.logs.viewport.Update(msg)
+		return m, cmd
+	}
+	if m.taskMode {
+		m.tasks.output, cmd = m.tasks.output.Update(msg)
+		return m, cmd
+	}
+
 	// Let table handle mouse events when not editing
 	if !m.editMode {
 		m.table, cmd = m.table.Update(msg)
@@ -384,22 +676,30 @@ func (m model) updateDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
 		// Confirm deletion
-		if m.deleteIndex >= 0 && m.deleteIndex < len(m.projects) {
-			projectName := m.projects[m.deleteIndex].Name
-			m.projects = append(m.projects[:m.deleteIndex], m.projects[m.deleteIndex+1:]...)
-			m.saveProjects()
+		if deleteIndex := m.projectIndexByID(m.deleteID); deleteIndex != -1 {
+			projectName := m.projects[deleteIndex].Name
+			before := m.snapshotProjects()
+			m.projects = append(m.projects[:deleteIndex], m.projects[deleteIndex+1:]...)
+			err := m.saveProjects()
+			if err != nil {
+				m.projects = before
+			}
 			m.updateTable()
 			m.confirmDelete = false
-			m.deleteIndex = -1
+			m.deleteID = ""
+			if err != nil {
+				return m, saveFailed(err)
+			}
+			m.recordChange("delete "+projectName, before)
 			return m, showStatus(fmt.Sprintf("🗑️ Deleted %s", projectName))
 		}
 		m.confirmDelete = false
-		m.deleteIndex = -1
+		m.deleteID = ""
 		return m, nil
 	case "n", "N", "esc":
 		// Cancel deletion
 		m.confirmDelete = false
-		m.deleteIndex = -1
+		m.deleteID = ""
 		return m, showStatus("❌ Deletion cancelled")
 	}
 	return m, nil
@@ -411,12 +711,17 @@ func (m model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.cancelEdit()
 		return m, nil
 	case "enter":
-		m.saveEdit()
+		err := m.saveEdit()
 		m.cancelEdit()
+		if err != nil {
+			return m, saveFailed(err)
+		}
 		return m, showStatus("✅ Project updated")
 	case "tab":
 		// Save current field and move to next
-		m.saveEdit()
+		if err := m.saveEdit(); err != nil {
+			return m, saveFailed(err)
+		}
 		m.editCol = (m.editCol + 1) % 5
 		project := m.projects[m.editRow]
 		var newValue string
@@ -426,7 +731,7 @@ func (m model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case 1:
 			newValue = project.Path
 		case 2:
-			newValue = project.Command
+			newValue = project.activeCommand()
 		case 3:
 			newValue = project.Link
 		case 4:
@@ -434,10 +739,13 @@ func (m model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.textInput.SetValue(newValue)
 		m.textInput.SetCursor(len(newValue)) // Move cursor to end
+		m.loadSuggestions()
 		return m, nil
 	case "shift+tab":
 		// Save current field and move to previous
-		m.saveEdit()
+		if err := m.saveEdit(); err != nil {
+			return m, saveFailed(err)
+		}
 		m.editCol = (m.editCol - 1 + 5) % 5
 		project := m.projects[m.editRow]
 		var newValue string
@@ -447,7 +755,7 @@ func (m model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case 1:
 			newValue = project.Path
 		case 2:
-			newValue = project.Command
+			newValue = project.activeCommand()
 		case 3:
 			newValue = project.Link
 		case 4:
@@ -455,6 +763,16 @@ func (m model) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.textInput.SetValue(newValue)
 		m.textInput.SetCursor(len(newValue)) // Move cursor to end
+		m.loadSuggestions()
+		return m, nil
+	case "up", "ctrl+p", "down", "ctrl+n":
+		if len(m.suggestions) > 0 {
+			step := 1
+			if msg.String() == "up" || msg.String() == "ctrl+p" {
+				step = -1
+			}
+			m.cycleSuggestion(step)
+		}
 		return m, nil
 	}
 
@@ -473,14 +791,23 @@ func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "n", "a":
 		// Add new project
 		newProject := Project{
+			ID:       newProjectID(),
 			Name:     "New Project",
 			Path:     "/path/to/project",
 			Command:  "command",
 			Link:     "",
 			Category: "", // Empty category will display as "N/A"
 		}
+		if m.loadErr != nil {
+			return m, saveFailed(fmt.Errorf("config failed to load: %w", m.loadErr))
+		}
+		before := m.snapshotProjects()
 		m.projects = append(m.projects, newProject)
-		m.saveProjects()
+		if err := m.saveProjects(); err != nil {
+			m.projects = before
+			return m, saveFailed(err)
+		}
+		m.recordChange("add "+newProject.Name, before)
 		m.updateTable()
 		// Find the display index of the newly added project
 		displayIndex := m.findProjectDisplayIndex(newProject)
@@ -489,6 +816,74 @@ func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.startEdit()
 		}
 		return m, showStatus("➕ New project added")
+	case "c":
+		// Duplicate the selected project
+		displayIndex := m.table.Cursor()
+		project := m.getProjectByDisplayIndex(displayIndex)
+		if project == nil {
+			return m, nil
+		}
+		duplicate := *project
+		duplicate.ID = newProjectID()
+		duplicate.Name = project.Name + " (copy)"
+		before := m.snapshotProjects()
+		m.projects = append(m.projects, duplicate)
+		if err := m.saveProjects(); err != nil {
+			m.projects = before
+			return m, saveFailed(err)
+		}
+		m.recordChange("duplicate "+project.Name, before)
+		m.updateTable()
+		if displayIndex := m.findProjectDisplayIndex(duplicate); displayIndex != -1 {
+			m.table.SetCursor(displayIndex)
+		}
+		return m, showStatus(fmt.Sprintf("📋 Duplicated %s", project.Name))
+	case "f":
+		return m, m.openDiscovery()
+	case "t":
+		project := m.getProjectByDisplayIndex(m.table.Cursor())
+		if project == nil {
+			return m, nil
+		}
+		return m, m.openTasks(*project)
+	case "u":
+		return m, m.undoLast()
+	case "ctrl+r":
+		return m, m.redoLast()
+	case "/":
+		m.filterMode = true
+		m.filterInput.SetValue(m.filter)
+		m.filterInput.CursorEnd()
+		return m, m.filterInput.Focus()
+	case "esc":
+		if m.activeFailure() != nil {
+			m.failure = nil
+		} else if m.filter != "" {
+			m.clearFilter()
+		}
+		return m, nil
+	case "L":
+		m.showFailureOutput()
+		return m, nil
+	case "i":
+		if project := m.getProjectByDisplayIndex(m.table.Cursor()); project != nil {
+			resolved, err := project.withProfile("")
+			if err != nil {
+				return m, showStatus(fmt.Sprintf("❌ %v", err))
+			}
+			return m, m.runInteractive(resolved)
+		}
+		return m, nil
+	case "E":
+		if project := m.getProjectByDisplayIndex(m.table.Cursor()); project != nil {
+			return m, m.openInEditor(*project)
+		}
+		return m, nil
+	case "!":
+		if project := m.getProjectByDisplayIndex(m.table.Cursor()); project != nil {
+			return m, m.openShell(*project)
+		}
+		return m, nil
 	case "d", "delete":
 		if len(m.projects) > 0 {
 			displayIndex := m.table.Cursor()
@@ -497,7 +892,7 @@ func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.confirmDelete = true
-			m.deleteIndex = originalIndex
+			m.deleteID = m.projects[originalIndex].ID
 			return m, showStatus(fmt.Sprintf("❓ Delete '%s'? (y/n)", m.projects[originalIndex].Name))
 		}
 		return m, nil
@@ -506,14 +901,96 @@ func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			displayIndex := m.table.Cursor()
 			project := m.getProjectByDisplayIndex(displayIndex)
 			if project != nil {
-				return m, m.launchProject(*project)
+				return m, m.launchProfile(*project, "")
 			}
 		}
 		return m, nil
+	case "g":
+		return m, m.openGroups()
+	case "v":
+		project := m.getProjectByDisplayIndex(m.table.Cursor())
+		if project == nil {
+			return m, nil
+		}
+		return m, m.openEnvPreview(*project)
+	case "p", "shift+enter":
+		project := m.getProjectByDisplayIndex(m.table.Cursor())
+		if project == nil {
+			return m, nil
+		}
+		return m, m.openProfilePicker(*project)
 	case "r":
-		m.projects = loadProjects(m.configFile)
+		m.settings, m.settingsErr = loadSettings(settingsFileFor(m.configFile))
+		m.store.historyLimit = m.settings.historyCount()
+		m.loadProjects()
 		m.updateTable()
+		if m.loadErr != nil {
+			return m, showStatus(fmt.Sprintf("❌ Failed to load config: %v", m.loadErr))
+		}
+		if m.settingsErr != nil {
+			return m, showStatus(fmt.Sprintf("❌ Failed to load settings: %v", m.settingsErr))
+		}
 		return m, showStatus("🔄 Refreshed")
+	case "s":
+		if len(m.projects) > 0 {
+			displayIndex := m.table.Cursor()
+			project := m.getProjectByDisplayIndex(displayIndex)
+			if project != nil {
+				escalate, err := m.processes.stop(processKey(*project), m.settings.stopGrace(), false)
+				if errors.Is(err, errNotRunning) && m.processes.cancelRestart(processKey(*project)) {
+					m.updateTable()
+					return m, showStatus(fmt.Sprintf("⏹️ Cancelled restarts of %s", project.Name))
+				}
+				if err != nil {
+					return m, showStatus(fmt.Sprintf("❌ Failed to stop %s: %v", project.Name, err))
+				}
+				m.updateTable()
+				return m, tea.Batch(showStatus(fmt.Sprintf("⏹️ Stopping %s", project.Name)), escalate)
+			}
+		}
+		return m, nil
+	case "S":
+		if len(m.projects) > 0 {
+			displayIndex := m.table.Cursor()
+			project := m.getProjectByDisplayIndex(displayIndex)
+			if project != nil {
+				if err := m.processes.kill(processKey(*project)); err != nil {
+					return m, showStatus(fmt.Sprintf("❌ Failed to kill %s: %v", project.Name, err))
+				}
+				m.updateTable()
+				return m, showStatus(fmt.Sprintf("💀 Killed %s", project.Name))
+			}
+		}
+		return m, nil
+	case "R":
+		if len(m.projects) > 0 {
+			displayIndex := m.table.Cursor()
+			project := m.getProjectByDisplayIndex(displayIndex)
+			if project != nil {
+				key := processKey(*project)
+				if !m.processes.isRunning(key) {
+					return m, m.launchProfile(*project, "")
+				}
+				escalate, err := m.processes.stop(key, m.settings.stopGrace(), true)
+				if err != nil {
+					return m, showStatus(fmt.Sprintf("❌ Failed to restart %s: %v", project.Name, err))
+				}
+				m.updateTable()
+				return m, tea.Batch(showStatus(fmt.Sprintf("🔁 Restarting %s", project.Name)), escalate)
+			}
+		}
+		return m, nil
+	case "l":
+		if len(m.projects) > 0 {
+			displayIndex := m.table.Cursor()
+			project := m.getProjectByDisplayIndex(displayIndex)
+			if project != nil {
+				m.openLogs(*project)
+			}
+		}
+		return m, nil
+	case "h":
+		return m, m.openHistory()
 	case "o":
 		if len(m.projects) > 0 {
 			displayIndex := m.table.Cursor()
@@ -549,33 +1026,35 @@ func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
 	}
-
-	return m, nil
 }
 
-func (m model) launchProject(project Project) tea.Cmd {
-	// Check if this is a Windows path (starts with /mnt/c/)
-	isWindowsPath := strings.HasPrefix(project.Path, "/mnt/c/")
+// buildLaunchCommand prepares the command that launches a project, without
+// starting it. For Windows projects it also says how PowerShell starts them.
+func buildLaunchCommand(project Project) (cmd *exec.Cmd, windowsMethod string, err error) {
+	env, err := launchEnv(project)
+	if err != nil {
+		return nil, "", err
+	}
 
-	var cmd *exec.Cmd
+	// Windows drives run through PowerShell unless the project's platform says otherwise
+	isWindowsPath := project.runsOnWindows()
 
 	if isWindowsPath {
-		windowsPath := strings.ReplaceAll(project.Path, "/mnt/c", "C:")
-		windowsPath = strings.ReplaceAll(windowsPath, "/", "\\")
-
-		// Use PowerShell for everything, but with different approaches
-		if strings.HasSuffix(project.Command, ".exe") {
-			// For .exe files, use Start-Process which is PowerShell's way to launch executables
-			psCommand := fmt.Sprintf(`Set-Location '%s'; Start-Process '%s'`, windowsPath, project.Command)
-			cmd = exec.Command("powershell.exe", "-Command", psCommand)
-		} else {
-			// For scripts like Python, use direct execution
-			psCommand := fmt.Sprintf(`Set-Location '%s'; %s`, windowsPath, project.Command)
-			cmd = exec.Command("powershell.exe", "-Command", psCommand)
+		windowsPath, err := currentWSLPaths().toWindows(project.Path)
+		if err != nil {
+			return nil, "", err
+		}
+		// Programs, scripts and shortcuts go through Start-Process, anything
+		// else like "python main.py" runs directly
+		psCommand, method, err := project.powerShellScript(windowsPath)
+		if err != nil {
+			return nil, "", err
 		}
+		cmd = exec.Command("powershell.exe", "-Command", psCommand)
+		windowsMethod = method
 	} else {
 		// For Linux/WSL apps, use bash
-		cmdString := fmt.Sprintf(`cd '%s' && %s`, project.Path, project.Command)
+		cmdString := fmt.Sprintf(`cd %s && %s`, shellQuote(project.Path), project.Command)
 
 		cmd = exec.Command("bash", "-c", cmdString)
 		cmd.Dir = project.Path
@@ -586,21 +1065,80 @@ func (m model) launchProject(project Project) tea.Cmd {
 			Pgid:    0,    // Use PID as PGID (makes it group leader)
 		}
 	}
+	cmd.Env = environ(env)
+	if shared := wslenv(env); isWindowsPath && shared != "" {
+		cmd.Env = append(cmd.Env, "WSLENV="+shared)
+	}
 
-	err := cmd.Start()
+	return cmd, windowsMethod, nil
+}
 
+func (m model) launchProject(project Project) tea.Cmd {
+	if project.Interactive {
+		return m.runInteractive(project)
+	}
+	if project.Terminal != "" {
+		return m.launchInTerminal(project)
+	}
+	// A second launch would replace the first in the registry, orphaning it
+	if m.processes.isRunning(processKey(project)) {
+		return showStatus(fmt.Sprintf("⚠️ %s is already running, R restarts it", project.Name))
+	}
+	// Started by the CLI or another launcher, so it isn't in the registry
+	if record, ok := readRunRecord(processKey(project)); ok && record.alive() {
+		return showStatus(fmt.Sprintf("⚠️ %s is already running outside this launcher (pid %d)", project.Name, record.PID))
+	}
+	cmd, windowsMethod, err := buildLaunchCommand(project)
 	if err != nil {
 		return showStatus(fmt.Sprintf("❌ Failed to launch %s: %v", project.Name, err))
 	}
 
-	if isWindowsPath {
-		method := "PowerShell"
-		if strings.HasSuffix(project.Command, ".exe") {
-			method = "PowerShell Start-Process"
+	// Capture output into the project's log; a broken log location shouldn't block the launch
+	logNote := ""
+	logs, logErr := openLaunchLog(logPathFor(project, m.settings), m.settings.logMaxBytes(), m.settings.logRetention())
+	if logErr != nil {
+		logNote = fmt.Sprintf(" (logging disabled: %v)", logErr)
+	} else {
+		logs.writeLaunch(project.Command)
+		cmd.Stdout = logs.streamWriter("stdout")
+		cmd.Stderr = logs.streamWriter("stderr")
+		logNote = " → Log: " + logs.path
+	}
+
+	err = cmd.Start()
+
+	if err != nil {
+		if logs != nil {
+			logs.writeLine("launcher", fmt.Sprintf("=== Failed to start: %v ===", err))
+			logs.Close()
 		}
-		return showStatus(fmt.Sprintf("🚀 Launched %s (Windows via %s)", project.Name, method))
+		return showStatus(fmt.Sprintf("❌ Failed to launch %s: %v", project.Name, err))
+	}
+
+	reap := m.processes.track(processKey(project), project.profile, cmd, logs)
+	writeRunRecord(project, cmd.Process.Pid, logs)
+
+	name := project.Name
+	if project.profile != "" {
+		name += " (" + project.profile + ")"
+	}
+
+	if project.Ready != nil {
+		logPath := ""
+		if logs != nil {
+			logPath = logs.path
+		}
+		prober, err := newReadyProber(*project.Ready, project.Path, logPath)
+		if err != nil {
+			return tea.Batch(showStatus(fmt.Sprintf("❌ Readiness probe for %s: %v", name, err)), reap)
+		}
+		reap = tea.Batch(reap, m.processes.probe(processKey(project), name, prober, project.startsDetached()))
+	}
+
+	if windowsMethod != "" {
+		return tea.Batch(showStatus(fmt.Sprintf("🚀 Launched %s (Windows via %s)%s", name, windowsMethod, logNote)), reap)
 	} else {
-		return showStatus(fmt.Sprintf("🚀 Launched %s", project.Name))
+		return tea.Batch(showStatus(fmt.Sprintf("🚀 Launched %s%s", name, logNote)), reap)
 	}
 }
 
@@ -609,17 +1147,19 @@ func (m model) openProjectLink(project Project) tea.Cmd {
 		return showStatus("📭 No Link Associated")
 	}
 
-	// WSL2 - use cmd.exe to open default browser on Windows
-	cmd := exec.Command("cmd.exe", "/c", "start", project.Link)
-	err := cmd.Start()
-
-	if err != nil {
+	if err := openLink(project.Link); err != nil {
 		return showStatus(fmt.Sprintf("❌ Failed to open link: %v", err))
 	}
 
 	return showStatus(fmt.Sprintf("🌐 Opened %s link in browser", project.Name))
 }
 
+func openLink(link string) error {
+	// WSL2 - use cmd.exe to open default browser on Windows
+	cmd := exec.Command("cmd.exe", "/c", "start", link)
+	return cmd.Start()
+}
+
 func (m *model) getSortedProjects() []Project {
 	// Create a copy of projects for sorting without modifying the original order
 	sortedProjects := make([]Project, len(m.projects))
@@ -650,51 +1190,18 @@ func (m *model) getSortedProjects() []Project {
 }
 
 func (m *model) getProjectByDisplayIndex(displayIndex int) *Project {
-	// Check if the display index is valid and not a header row
-	if displayIndex < 0 || displayIndex >= len(m.projectIndices) {
-		return nil
-	}
-
-	// Get the actual project index (-1 means header row)
-	projectIndex := m.projectIndices[displayIndex]
-	if projectIndex == -1 {
-		return nil // This is a header row, no project associated
-	}
-
-	sortedProjects := m.getSortedProjects()
-	if projectIndex >= len(sortedProjects) {
+	index := m.getOriginalIndexByDisplayIndex(displayIndex)
+	if index == -1 {
 		return nil
 	}
-
-	// Find the original project in m.projects that matches the sorted project
-	sortedProject := sortedProjects[projectIndex]
-	for i := range m.projects {
-		if m.projects[i].Name == sortedProject.Name &&
-			m.projects[i].Path == sortedProject.Path &&
-			m.projects[i].Command == sortedProject.Command {
-			return &m.projects[i]
-		}
-	}
-	return nil
+	return &m.projects[index]
 }
 
 func (m *model) findProjectDisplayIndex(targetProject Project) int {
 	// Find the display index of a project in the table
-	for i, projectIndex := range m.projectIndices {
-		if projectIndex == -1 {
-			continue // Skip header rows
-		}
-
-		sortedProjects := m.getSortedProjects()
-		if projectIndex < len(sortedProjects) {
-			project := sortedProjects[projectIndex]
-			if project.Name == targetProject.Name &&
-				project.Path == targetProject.Path &&
-				project.Command == targetProject.Command &&
-				project.Link == targetProject.Link &&
-				project.Category == targetProject.Category {
-				return i
-			}
+	for i, id := range m.rowProjectIDs {
+		if id != "" && id == targetProject.ID {
+			return i
 		}
 	}
 	return -1
@@ -702,27 +1209,22 @@ func (m *model) findProjectDisplayIndex(targetProject Project) int {
 
 func (m *model) getOriginalIndexByDisplayIndex(displayIndex int) int {
 	// Check if the display index is valid and not a header row
-	if displayIndex < 0 || displayIndex >= len(m.projectIndices) {
+	if displayIndex < 0 || displayIndex >= len(m.rowProjectIDs) {
 		return -1
 	}
 
-	// Get the actual project index (-1 means header row)
-	projectIndex := m.projectIndices[displayIndex]
-	if projectIndex == -1 {
+	// Get the project ID ("" means header row)
+	id := m.rowProjectIDs[displayIndex]
+	if id == "" {
 		return -1 // This is a header row, no project associated
 	}
+	return m.projectIndexByID(id)
+}
 
-	sortedProjects := m.getSortedProjects()
-	if projectIndex >= len(sortedProjects) {
-		return -1
-	}
-
-	// Find the original index in m.projects that matches the sorted project
-	sortedProject := sortedProjects[projectIndex]
+// projectIndexByID returns the index in m.projects of the project with id
+func (m *model) projectIndexByID(id string) int {
 	for i := range m.projects {
-		if m.projects[i].Name == sortedProject.Name &&
-			m.projects[i].Path == sortedProject.Path &&
-			m.projects[i].Command == sortedProject.Command {
+		if m.projects[i].ID == id {
 			return i
 		}
 	}
@@ -733,12 +1235,6 @@ func (m model) View() string {
 	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).
 		Render("🚀 Project Launcher")
 
-	if len(m.projects) == 0 {
-		content := "\nNo projects configured yet.\n\nPress 'n' to add your first project!"
-		footer := "n: add new project • q: quit"
-		return fmt.Sprintf("%s\n%s\n\n%s", header, content, footer)
-	}
-
 	var statusMessage string
 	if m.statusMsg != "" && time.Now().Before(m.statusExpiry) {
 		// Color code based on message type
@@ -749,11 +1245,55 @@ func (m model) View() string {
 		statusStyle := lipgloss.NewStyle().Foreground(color)
 		statusMessage = " > " + statusStyle.Render(m.statusMsg)
 	}
+	if failure := m.activeFailure(); failure != nil && !m.logMode {
+		statusMessage = strings.TrimSuffix(failure.view(m.width)+"\n"+statusMessage, "\n")
+	}
+
+	if m.logMode {
+		return m.viewLogs(statusMessage)
+	}
+	if m.historyMode {
+		return m.viewHistory(statusMessage)
+	}
+	if m.discoverMode {
+		return m.viewDiscovery(statusMessage)
+	}
+	if m.taskMode {
+		return m.viewTasks(statusMessage)
+	}
+	if m.profileMode {
+		return m.viewProfilePicker(statusMessage)
+	}
+	if m.envMode {
+		return m.viewEnvPreview(statusMessage)
+	}
+	if m.groupMode {
+		return m.viewGroups(statusMessage)
+	}
+
+	if m.settingsErr != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		header += "\n" + errStyle.Render(fmt.Sprintf("❌ Settings not loaded, using defaults until fixed (r reloads): %v", m.settingsErr))
+	}
+	if m.loadErr != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		header += "\n" + errStyle.Render(fmt.Sprintf("❌ Config not loaded, saving is disabled until it is fixed: %v", m.loadErr))
+		if len(m.projects) == 0 {
+			footer := "r: reload config • q: quit"
+			return fmt.Sprintf("%s\n\n%s\n%s", header, footer, statusMessage)
+		}
+	}
+
+	if len(m.projects) == 0 {
+		content := "\nNo projects configured yet.\n\nPress 'n' to add your first project!"
+		footer := "n: add new project • f: find projects • h: history • q: quit"
+		return fmt.Sprintf("%s\n%s\n\n%s\n%s", header, content, footer, statusMessage)
+	}
 
 	// Show different footer based on mode
 	var footer string
 	if m.editMode {
-		colName := []string{"Name", "Path", "Command", "Link", "Category"}[m.editCol]
+		colName := editFieldNames[m.editCol]
 		// Color the keys in edit mode
 		keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")) // Blue color for keys
 		footer = fmt.Sprintf("Editing %s: %s | %s: next field • %s: save • %s: cancel",
@@ -762,45 +1302,61 @@ func (m model) View() string {
 			keyStyle.Render("tab"),
 			keyStyle.Render("enter"),
 			keyStyle.Render("esc"))
+		if m.editCol == 2 && len(m.suggestions) > 0 {
+			position := "typed"
+			if m.suggestion >= 0 {
+				position = fmt.Sprintf("%d/%d, %s", m.suggestion+1, len(m.suggestions), m.suggestions[m.suggestion].Reason)
+			}
+			footer += fmt.Sprintf("\n%s: cycle %d suggested commands (%s)", keyStyle.Render("↑↓"), len(m.suggestions), position)
+		}
+	} else if m.filterMode {
+		footer = fmt.Sprintf("Filter: %s | %s\n%s", m.filterInput.View(), renderKeyHints([]keyHint{
+			{"enter", "launch top hit"},
+			{"↑↓", "move"},
+			{"tab", "browse matches"},
+			{"esc", "clear"},
+		}), statusMessage)
 	} else {
-		// Color styles
-		keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))     // Blue color for keys
-		actionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86"))  // Green color for action text
-		bulletStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")) // Gray color for bullets
-
-		scrollHint := ""
+		hints := []keyHint{{"↑↓", "navigate"}}
 		if m.maxCols > len(m.table.Columns()) {
-			scrollHint = " " + bulletStyle.Render("•") + " " + keyStyle.Render("←→") + ": " + actionStyle.Render("scroll columns")
+			hints = append(hints, keyHint{"←→", "scroll columns"})
 		}
-
-		footer = fmt.Sprintf("%s: %s%s %s %s/%s: %s %s %s: %s\n%s/%s: %s %s %s/%s: %s %s %s: %s %s %s: %s %s %s: %s\n%s",
-			keyStyle.Render("↑↓"),
-			actionStyle.Render("navigate"),
-			scrollHint,
-			bulletStyle.Render("•"),
-			keyStyle.Render("space"),
-			keyStyle.Render("enter"),
-			actionStyle.Render("launch"),
-			bulletStyle.Render("•"),
-			keyStyle.Render("e"),
-			actionStyle.Render("edit"),
-			keyStyle.Render("n"),
-			keyStyle.Render("a"),
-			actionStyle.Render("add"),
-			bulletStyle.Render("•"),
-			keyStyle.Render("d"),
-			keyStyle.Render("delete"),
-			actionStyle.Render("delete"),
-			bulletStyle.Render("•"),
-			keyStyle.Render("r"),
-			actionStyle.Render("refresh"),
-			bulletStyle.Render("•"),
-			keyStyle.Render("o"),
-			actionStyle.Render("open link"),
-			bulletStyle.Render("•"),
-			keyStyle.Render("q"),
-			actionStyle.Render("quit"),
-			statusMessage)
+		hints = append(hints,
+			keyHint{"space/enter", "launch"},
+			keyHint{"e", "edit"},
+			keyHint{"n/a", "add"},
+			keyHint{"c", "duplicate"},
+			keyHint{"d/delete", "delete"},
+		)
+		if label := m.undo.nextUndo(); label != "" {
+			hints = append(hints, keyHint{"u", "undo " + label})
+		}
+		if label := m.undo.nextRedo(); label != "" {
+			hints = append(hints, keyHint{"ctrl+r", "redo " + label})
+		}
+		if m.filter != "" {
+			hints = append(hints, keyHint{"esc", fmt.Sprintf("clear filter %q", m.filter)})
+		} else {
+			hints = append(hints, keyHint{"/", "filter"})
+		}
+		footer = renderKeyHints(hints) + "\n" + renderKeyHints([]keyHint{
+			{"s", "stop"},
+			{"S", "kill"},
+			{"R", "restart"},
+			{"l", "logs"},
+			{"i", "run interactively"},
+			{"!", "shell"},
+			{"E", "editor"},
+			{"t", "tasks"},
+			{"p", "profiles"},
+			{"v", "env"},
+			{"g", "groups"},
+			{"h", "history"},
+			{"f", "find projects"},
+			{"r", "refresh"},
+			{"o", "open link"},
+			{"q", "quit"},
+		}) + "\n" + statusMessage
 	}
 
 	// If editing, overlay the input on the table
@@ -810,5 +1366,29 @@ func (m model) View() string {
 		tableView = m.table.View()
 	}
 
+	if project := m.getProjectByDisplayIndex(m.table.Cursor()); project != nil && !m.editMode {
+		if notice := m.crashNotice(*project); notice != "" {
+			tableView += "\n\n" + notice
+		}
+	}
+
 	return fmt.Sprintf("%s\n\n%s\n\n%s", header, tableView, footer)
 }
+
+// keyHint is one "key: action" entry in the footer
+type keyHint struct {
+	key    string
+	action string
+}
+
+func renderKeyHints(hints []keyHint) string {
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))     // Blue color for keys
+	actionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86"))  // Green color for action text
+	bulletStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")) // Gray color for bullets
+
+	parts := make([]string, len(hints))
+	for i, hint := range hints {
+		parts[i] = keyStyle.Render(hint.key) + ": " + actionStyle.Render(hint.action)
+	}
+	return strings.Join(parts, " "+bulletStyle.Render("•")+" ")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestModel builds a model with enough of the table set up for updateTable
+func newTestModel(projects []Project) model {
+	columns := []table.Column{{Title: "Name", Width: 20}, {Title: "Status", Width: 20}, {Title: "Path", Width: 20}, {Title: "Command", Width: 20}}
+	return model{
+		projects:   projects,
+		processes:  newProcessRegistry(),
+		table:      table.New(table.WithColumns(columns)),
+		allColumns: columns,
+		textInput:  textinput.New(),
+		editRow:    -1,
+		editCol:    -1,
+	}
+}
+
+func TestSaveEditRollsBackOnFailure(t *testing.T) {
+	m := newTestModel([]Project{{ID: "a", Name: "API", Path: "/srv/api", Command: "make run"}})
+	m.loadErr = errors.New("broken config") // Saving is refused while the config is broken
+	m.editMode, m.editRow, m.editCol = true, 0, 0
+	m.textInput.SetValue("Renamed")
+
+	if err := m.saveEdit(); err == nil {
+		t.Fatal("saveEdit succeeded with a broken config")
+	}
+	if name := m.projects[0].Name; name != "API" {
+		t.Errorf("name is %q after a failed save, want it rolled back to API", name)
+	}
+	if label := m.undo.nextUndo(); label != "" {
+		t.Errorf("failed save recorded undo entry %q", label)
+	}
+}
+
+func TestEditSessionTakesOneSnapshot(t *testing.T) {
+	tests := []struct {
+		name          string
+		values        []string // Typed into each field before tab, "" keeps it
+		wantSnapshots int
+	}{
+		{"one field", []string{"API 2"}, 1},
+		{"every field", []string{"API 2", "/srv/api2", "make dev", "http://localhost", "backend"}, 1},
+		{"no change", []string{"", "", ""}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel([]Project{{ID: "a", Name: "API", Path: "/srv/api", Command: "make run"}})
+			m.store = newProjectStore(filepath.Join(t.TempDir(), "config.json"), 50)
+			if err := m.store.Save(m.projects); err != nil {
+				t.Fatal(err)
+			}
+			m.updateTable()
+			m.table.SetCursor(slices.Index(m.rowProjectIDs, "a")) // Below the category header
+
+			m.startEdit()
+			for _, value := range tt.values {
+				if value != "" {
+					m.textInput.SetValue(value)
+				}
+				updated, _ := m.updateEdit(tea.KeyMsg{Type: tea.KeyTab})
+				m = updated.(model)
+			}
+			updated, _ := m.updateEdit(tea.KeyMsg{Type: tea.KeyEnter})
+			m = updated.(model)
+
+			snapshots, err := m.store.Snapshots()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(snapshots) != tt.wantSnapshots {
+				t.Errorf("%d snapshots after one edit session, want %d", len(snapshots), tt.wantSnapshots)
+			}
+			if m.store.session {
+				t.Error("edit session still open after enter")
+			}
+		})
+	}
+}
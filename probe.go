@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ReadyProbe says how to tell that a launched project is ready to serve.
+// Every configured check has to pass.
+type ReadyProbe struct {
+	TCP            string `json:"tcp,omitempty"`  // host:port that accepts connections
+	HTTP           string `json:"http,omitempty"` // URL that answers with a 2xx status
+	Log            string `json:"log,omitempty"`  // Regular expression matched by an output line
+	File           string `json:"file,omitempty"` // Path that exists, relative to the working directory
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+}
+
+const (
+	defaultProbeTimeout = 60 * time.Second
+	probeInterval       = 500 * time.Millisecond
+)
+
+var (
+	errProbeTimeout = errors.New("not ready before timeout")
+	errProbeExited  = errors.New("exited before it was ready")
+)
+
+func (p ReadyProbe) timeout() time.Duration {
+	if p.TimeoutSeconds <= 0 {
+		return defaultProbeTimeout
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
+// describe summarises the checks for status messages
+func (p ReadyProbe) describe() string {
+	var checks []string
+	if p.TCP != "" {
+		checks = append(checks, "tcp "+p.TCP)
+	}
+	if p.HTTP != "" {
+		checks = append(checks, "http "+p.HTTP)
+	}
+	if p.Log != "" {
+		checks = append(checks, fmt.Sprintf("log /%s/", p.Log))
+	}
+	if p.File != "" {
+		checks = append(checks, "file "+p.File)
+	}
+	return strings.Join(checks, ", ")
+}
+
+// readyProber runs the checks of one probe against one launch
+type readyProber struct {
+	probe   ReadyProbe
+	dir     string
+	logRe   *regexp.Regexp
+	logs    *logFollower
+	client  *http.Client
+	matched bool // The log check passed; it stays passed
+}
+
+func newReadyProber(probe ReadyProbe, dir, logPath string) (*readyProber, error) {
+	p := &readyProber{
+		probe:  probe,
+		dir:    dir,
+		client: &http.Client{Timeout: 2 * time.Second},
+	}
+	if probe.Log != "" {
+		re, err := regexp.Compile(probe.Log)
+		if err != nil {
+			return nil, fmt.Errorf("bad log pattern: %w", err)
+		}
+		p.logRe = re
+		p.logs = &logFollower{path: logPath, offset: -1}
+	}
+	return p, nil
+}
+
+// check runs every check once, returning why the project isn't ready yet
+func (p *readyProber) check() error {
+	if p.probe.TCP != "" {
+		conn, err := net.DialTimeout("tcp", p.probe.TCP, time.Second)
+		if err != nil {
+			return fmt.Errorf("tcp %s: %w", p.probe.TCP, err)
+		}
+		conn.Close()
+	}
+	if p.probe.HTTP != "" {
+		resp, err := p.client.Get(p.probe.HTTP)
+		if err != nil {
+			return fmt.Errorf("http: %w", err)
+		}
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("http %s: %s", p.probe.HTTP, resp.Status)
+		}
+	}
+	if p.logRe != nil && !p.matched {
+		lines, err := p.logs.newLines()
+		if err != nil {
+			return fmt.Errorf("log: %w", err)
+		}
+		for _, line := range lines {
+			if p.logRe.MatchString(line) {
+				p.matched = true
+				break
+			}
+		}
+		if !p.matched {
+			return fmt.Errorf("no log line matching /%s/", p.probe.Log)
+		}
+	}
+	if p.probe.File != "" {
+		path := expandHome(p.probe.File)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(p.dir, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("file %s does not exist", p.probe.File)
+		}
+	}
+	return nil
+}
+
+// waitReady polls the probe until it passes, its timeout runs out or alive
+// reports that the process is gone
+func (p *readyProber) waitReady(alive func() bool) error {
+	deadline := time.Now().Add(p.probe.timeout())
+	for {
+		if !alive() {
+			return errProbeExited
+		}
+		err := p.check()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w (%v)", errProbeTimeout, err)
+		}
+		time.Sleep(probeInterval)
+	}
+}
+
+// logFollower reads the lines appended to a project log by the current
+// launch, starting after its launch marker
+type logFollower struct {
+	path   string
+	offset int64 // -1 until the launch marker has been found
+}
+
+func (f *logFollower) newLines() ([]string, error) {
+	file, err := os.Open(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if f.offset > info.Size() {
+		f.offset = 0 // Rotated since the last read
+	}
+	start := max(f.offset, 0)
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	// Only complete lines count; a partial one is read again next time
+	if end := bytes.LastIndexByte(data, '\n'); end != -1 {
+		data = data[:end+1]
+	} else {
+		data = nil
+	}
+
+	if f.offset < 0 {
+		marker := bytes.LastIndex(data, []byte(launchMarker))
+		if marker == -1 {
+			return nil, nil
+		}
+		lineEnd := bytes.IndexByte(data[marker:], '\n')
+		data = data[marker+lineEnd+1:]
+		start += int64(marker + lineEnd + 1)
+	}
+	f.offset = start + int64(len(data))
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, nil
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type processState int
+
+const (
+	procRunning processState = iota
+	procExited
+	procCrashed
+	procStopped
+)
+
+// processInfo records everything the launcher knows about one launched process
+type processInfo struct {
+	PID       int
+	PGID      int
+	StartedAt time.Time
+	ExitedAt  time.Time
+	ExitCode  int
+	State     processState
+	LogPath   string
+	Profile   string // Launch profile, "" for projects without profiles
+	Probe     probeState
+	ProbeErr  error     // Why the readiness probe failed
+	RestartAt time.Time // When a restart policy relaunches the exited process
+	CrashLoop bool      // The restart policy gave up on the process
+	CrashTail []string  // Last output lines when the restart policy gave up
+	cmd       *exec.Cmd
+	stopping  bool // Stop was requested, so the exit is expected
+	restart   bool // Relaunch once the process has exited
+}
+
+// processRegistry tracks launched processes by project key. It is shared by
+// pointer so copies of the model all see the same registry.
+type processRegistry struct {
+	mu       sync.Mutex
+	procs    map[string]*processInfo
+	restarts map[string]*restartState
+}
+
+// probeState tracks the readiness probe of a running process
+type probeState int
+
+const (
+	probeNone probeState = iota // The project has no readiness probe
+	probeWaiting
+	probeReady
+	probeFailed
+)
+
+// probeResultMsg reports the outcome of a readiness probe
+type probeResultMsg struct {
+	key  string
+	name string
+	pid  int
+	err  error
+}
+
+type processExitedMsg struct {
+	key      string
+	pid      int
+	exitCode int
+	signaled bool
+	stopped  bool // Stopped from outside, with project-launcher stop
+}
+
+// stopEscalateMsg fires when the stop grace period for a process runs out
+type stopEscalateMsg struct {
+	key string
+	pid int
+}
+
+type tickMsg time.Time
+
+var errNotRunning = errors.New("not running")
+
+func newProcessRegistry() *processRegistry {
+	return &processRegistry{
+		procs:    make(map[string]*processInfo),
+		restarts: make(map[string]*restartState),
+	}
+}
+
+// processKey identifies a project in the registry
+func processKey(project Project) string {
+	return project.ID
+}
+
+// track registers a started command and returns a tea.Cmd that reaps it. The
+// launch log, if any, is closed once the process has been reaped.
+func (r *processRegistry) track(key, profile string, cmd *exec.Cmd, logs *launchLog) tea.Cmd {
+	pid := cmd.Process.Pid
+	pgid, err := syscall.Getpgid(pid)
+	if err != nil || pgid == syscall.Getpgrp() {
+		pgid = 0 // Not in its own group, so only the process itself can be signalled
+	}
+
+	r.mu.Lock()
+	// A launch by hand starts the restart count over
+	if state := r.restarts[key]; state != nil && state.pending {
+		state.pending = false
+	} else {
+		delete(r.restarts, key)
+	}
+	r.procs[key] = &processInfo{
+		PID:       pid,
+		PGID:      pgid,
+		StartedAt: time.Now(),
+		State:     procRunning,
+		Profile:   profile,
+		cmd:       cmd,
+	}
+	if logs != nil {
+		r.procs[key].LogPath = logs.path
+	}
+	r.mu.Unlock()
+
+	return func() tea.Msg {
+		msg := processExitedMsg{key: key, pid: pid}
+		msg.exitCode, msg.signaled = exitStatus(cmd.Wait())
+		if logs != nil {
+			logs.finish(msg)
+		}
+		return msg
+	}
+}
+
+// exitStatus turns the result of cmd.Wait into an exit code. Processes killed
+// by a signal report 128+signal, like a shell does.
+func exitStatus(err error) (code int, signaled bool) {
+	if err == nil {
+		return 0, false
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), true
+		}
+		return exitErr.ExitCode(), false
+	}
+	return -1, true
+}
+
+// markExited records the exit of a reaped process and reports whether a restart
+// was requested for it. Exits of a process that has since been replaced by a
+// newer launch are ignored.
+func (r *processRegistry) markExited(msg processExitedMsg) (restart bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[msg.key]
+	if !ok || info.PID != msg.pid {
+		return false
+	}
+	info.ExitedAt = time.Now()
+	info.ExitCode = msg.exitCode
+	info.cmd = nil
+	switch {
+	case info.stopping, msg.stopped:
+		info.State = procStopped
+	case msg.signaled:
+		info.State = procCrashed
+	default:
+		info.State = procExited
+	}
+	restart = info.restart
+	info.stopping = false
+	info.restart = false
+	return restart
+}
+
+// signal sends sig to the whole process group, falling back to the process
+// itself when it does not lead its own group
+func (info *processInfo) signal(sig syscall.Signal) error {
+	if info.PGID > 0 {
+		return syscall.Kill(-info.PGID, sig)
+	}
+	return syscall.Kill(info.PID, sig)
+}
+
+// stop sends SIGTERM to a running process and returns a tea.Cmd that escalates
+// to SIGKILL once grace has elapsed. With restart set, the project is
+// relaunched after it exits.
+func (r *processRegistry) stop(key string, grace time.Duration, restart bool) (tea.Cmd, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[key]
+	if !ok || info.State != procRunning {
+		return nil, errNotRunning
+	}
+	if err := info.signal(syscall.SIGTERM); err != nil {
+		return nil, err
+	}
+	info.stopping = true
+	info.restart = info.restart || restart
+
+	pid := info.PID
+	return tea.Tick(grace, func(time.Time) tea.Msg {
+		return stopEscalateMsg{key: key, pid: pid}
+	}), nil
+}
+
+// kill sends SIGKILL to a running process immediately
+func (r *processRegistry) kill(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[key]
+	if !ok || info.State != procRunning {
+		return errNotRunning
+	}
+	info.stopping = true
+	return info.signal(syscall.SIGKILL)
+}
+
+// escalate force-kills a process that ignored SIGTERM past its grace period
+func (r *processRegistry) escalate(msg stopEscalateMsg) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[msg.key]
+	if !ok || info.PID != msg.pid || info.State != procRunning {
+		return false
+	}
+	return info.signal(syscall.SIGKILL) == nil
+}
+
+// probe starts waiting for the readiness probe of a freshly tracked process
+// and returns a tea.Cmd that reports the result. A detached launch is probed
+// until its timeout even though the tracked wrapper exits right away.
+func (r *processRegistry) probe(key, name string, prober *readyProber, detached bool) tea.Cmd {
+	r.mu.Lock()
+	info, ok := r.procs[key]
+	if !ok {
+		r.mu.Unlock()
+		return nil
+	}
+	info.Probe = probeWaiting
+	pid := info.PID
+	r.mu.Unlock()
+
+	alive := func() bool {
+		info, ok := r.get(key)
+		return ok && info.PID == pid && (info.State == procRunning || (detached && info.State == procExited && info.ExitCode == 0))
+	}
+	return func() tea.Msg {
+		return probeResultMsg{key: key, name: name, pid: pid, err: prober.waitReady(alive)}
+	}
+}
+
+// markProbed records the result of a readiness probe
+func (r *processRegistry) markProbed(msg probeResultMsg) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[msg.key]
+	if !ok || info.PID != msg.pid {
+		return
+	}
+	if msg.err != nil {
+		info.Probe = probeFailed
+		info.ProbeErr = msg.err
+	} else {
+		info.Probe = probeReady
+	}
+}
+
+// isRunning reports whether the process for key is still alive
+func (r *processRegistry) isRunning(key string) bool {
+	info, ok := r.get(key)
+	return ok && info.State == procRunning
+}
+
+// get returns a snapshot of the process info for a key
+func (r *processRegistry) get(key string) (processInfo, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[key]
+	if !ok {
+		return processInfo{}, false
+	}
+	return *info, true
+}
+
+func (r *processRegistry) anyRunning() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, info := range r.procs {
+		if info.State == procRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// statusText renders the Status column for a project
+func (r *processRegistry) statusText(key string) string {
+	info, ok := r.get(key)
+	if !ok || info.State != procRunning {
+		// Launched by the CLI or another launcher, known only from its run record
+		if record, found := readRunRecord(key); found && record.alive() {
+			text := fmt.Sprintf("🟢 running since %s (pid %d)", record.StartedAt.Format("15:04"), record.PID)
+			if record.Profile != "" {
+				text += " [" + record.Profile + "]"
+			}
+			return text
+		}
+	}
+	if !ok {
+		return ""
+	}
+	switch info.State {
+	case procRunning:
+		if info.stopping {
+			return "🟡 stopping"
+		}
+		icon, word := "🟢", "running"
+		switch info.Probe {
+		case probeWaiting:
+			icon, word = "🔵", "starting"
+		case probeReady:
+			word = "ready"
+		case probeFailed:
+			icon, word = "🔴", "not ready"
+		}
+		text := fmt.Sprintf("%s %s %s", icon, word, formatUptime(time.Since(info.StartedAt)))
+		if info.Profile != "" {
+			text += " [" + info.Profile + "]"
+		}
+		return text
+	}
+	switch {
+	case info.CrashLoop:
+		return fmt.Sprintf("🔁 crash loop (%d)", info.ExitCode)
+	case !info.RestartAt.IsZero():
+		return fmt.Sprintf("⏳ restart in %s", formatUptime(max(time.Until(info.RestartAt), 0)))
+	}
+	switch info.State {
+	case procStopped:
+		return "⏹️ stopped"
+	case procCrashed:
+		return "💥 crashed"
+	default:
+		return fmt.Sprintf("⚪ exited (%d)", info.ExitCode)
+	}
+}
+
+func formatUptime(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d.Hours())
+	mins := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh%02dm", h, mins)
+	case mins > 0:
+		return fmt.Sprintf("%dm%02ds", mins, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
+
+func tickEvery() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestLaunchRefusesRunningProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		tracked  bool // Running in this launcher's registry, otherwise only in a run record
+		wantMsg  string
+		wantCell string
+	}{
+		{"tracked by this launcher", true, "already running, R restarts it", "🟢 running"},
+		{"launched by the CLI", false, "already running outside this launcher", "🟢 running since"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_STATE_HOME", t.TempDir())
+			running := exec.Command("sleep", "30")
+			if err := running.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer running.Process.Kill()
+
+			m := model{processes: newProcessRegistry()}
+			project := Project{ID: "p1", Name: "API", Path: t.TempDir(), Command: "true"}
+			if tt.tracked {
+				m.processes.track(processKey(project), "", running, nil)
+			} else {
+				writeRunRecord(project, running.Process.Pid, nil)
+			}
+
+			msg, ok := m.launchProject(project)().(statusMsg)
+			if !ok || !strings.Contains(msg.message, tt.wantMsg) {
+				t.Fatalf("second launch was not refused: %#v", msg)
+			}
+			if record, _ := readRunRecord(processKey(project)); !tt.tracked && record.PID != running.Process.Pid {
+				t.Errorf("run record points at pid %d, want the original %d", record.PID, running.Process.Pid)
+			}
+			if cell := m.processes.statusText(processKey(project)); !strings.HasPrefix(cell, tt.wantCell) {
+				t.Errorf("status %q, want it to start with %q", cell, tt.wantCell)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Profile is a named way to run a project, e.g. with a debugger attached or
+// against a staging environment
+type Profile struct {
+	Name    string            `json:"name"`
+	Command string            `json:"command,omitempty"` // Defaults to the project's command
+	Env     map[string]string `json:"env,omitempty"`
+	Dir     string            `json:"dir,omitempty"` // Working directory relative to the project path
+}
+
+// defaultProfileIndex is the profile Enter launches: the one named by
+// DefaultProfile, else the first. It is -1 for projects without profiles.
+func (p Project) defaultProfileIndex() int {
+	if len(p.Profiles) == 0 {
+		return -1
+	}
+	for i, profile := range p.Profiles {
+		if profile.Name == p.DefaultProfile {
+			return i
+		}
+	}
+	return 0
+}
+
+// defaultProfileName is the name of the default profile, "" without profiles
+func (p Project) defaultProfileName() string {
+	if i := p.defaultProfileIndex(); i != -1 {
+		return p.Profiles[i].Name
+	}
+	return ""
+}
+
+// activeCommand is the command Enter runs: the default profile's own command
+// if it has one, else the project's
+func (p Project) activeCommand() string {
+	if i := p.defaultProfileIndex(); i != -1 && p.Profiles[i].Command != "" {
+		return p.Profiles[i].Command
+	}
+	return p.Command
+}
+
+// commandCell is the Command column text, naming the default profile
+func (p Project) commandCell() string {
+	if profile := p.defaultProfileName(); profile != "" {
+		return fmt.Sprintf("[%s] %s", profile, p.activeCommand())
+	}
+	return p.Command
+}
+
+// setActiveCommand changes the command shown in the Command column, which
+// belongs to the default profile when that profile has its own command
+func (p *Project) setActiveCommand(command string) {
+	if i := p.defaultProfileIndex(); i != -1 && p.Profiles[i].Command != "" {
+		// Copy so saved snapshots of the project list keep the old profile
+		p.Profiles = slices.Clone(p.Profiles)
+		p.Profiles[i].Command = command
+		return
+	}
+	p.Command = command
+}
+
+// withProfile returns the project as launched with the named profile, or the
+// default profile when name is empty
+func (p Project) withProfile(name string) (Project, error) {
+	index := p.defaultProfileIndex()
+	if name != "" {
+		index = slices.IndexFunc(p.Profiles, func(profile Profile) bool { return profile.Name == name })
+		if index == -1 {
+			return p, fmt.Errorf("%s has no profile %q", p.Name, name)
+		}
+	}
+	if index == -1 {
+		return p, nil
+	}
+
+	profile := p.Profiles[index]
+	resolved := p
+	resolved.profile = profile.Name
+	resolved.profileEnv = profile.Env
+	if profile.Command != "" {
+		resolved = resolved.withCommand(profile.Command)
+	}
+	if profile.Dir != "" {
+		// A relative log file stays relative to the project root
+		if resolved.LogFile != "" && !filepath.IsAbs(expandHome(resolved.LogFile)) {
+			resolved.LogFile = filepath.Join(p.Path, resolved.LogFile)
+		}
+		resolved.Path = filepath.Join(p.Path, profile.Dir)
+	}
+	return resolved, nil
+}
+
+// launchProfile launches project with the named profile, "" for its default
+func (m model) launchProfile(project Project, profile string) tea.Cmd {
+	resolved, err := project.withProfile(profile)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ %v", err))
+	}
+	return m.launchProject(resolved)
+}
+
+// profilePicker lets the user launch a profile other than the default
+type profilePicker struct {
+	projectID string
+	cursor    int
+}
+
+func (m *model) openProfilePicker(project Project) tea.Cmd {
+	if len(project.Profiles) == 0 {
+		return showStatus(fmt.Sprintf("🎛️ %s has no profiles, add them under \"profiles\" in the config", project.Name))
+	}
+	m.profiles = profilePicker{projectID: project.ID, cursor: project.defaultProfileIndex()}
+	m.profileMode = true
+	return nil
+}
+
+func (m model) updateProfilePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	index := m.projectIndexByID(m.profiles.projectID)
+	if index == -1 {
+		m.profileMode = false
+		return m, nil
+	}
+	project := m.projects[index]
+	p := &m.profiles
+
+	switch msg.String() {
+	case "q", "esc":
+		m.profileMode = false
+	case "up", "k":
+		p.cursor = max(p.cursor-1, 0)
+	case "down", "j":
+		p.cursor = min(p.cursor+1, len(project.Profiles)-1)
+	case "enter", " ":
+		m.profileMode = false
+		return m, m.launchProfile(project, project.Profiles[p.cursor].Name)
+	case "*":
+		// Make the selected profile the one Enter launches
+		name := project.Profiles[p.cursor].Name
+		before := m.snapshotProjects()
+		m.projects[index].DefaultProfile = name
+		if err := m.saveProjects(); err != nil {
+			m.projects = before
+			return m, saveFailed(err)
+		}
+		m.recordChange(fmt.Sprintf("default profile of %s", project.Name), before)
+		m.updateTable()
+		return m, showStatus(fmt.Sprintf("⭐ %s now launches %s by default", project.Name, name))
+	}
+	return m, nil
+}
+
+func (m model) viewProfilePicker(statusMessage string) string {
+	index := m.projectIndexByID(m.profiles.projectID)
+	if index == -1 {
+		return statusMessage
+	}
+	project := m.projects[index]
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).
+		Render("🎛️ Launch " + project.Name + " with profile")
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+	width := max(m.width-2, 40)
+	defaultIndex := project.defaultProfileIndex()
+	var rows []string
+	for i, profile := range project.Profiles {
+		marker := " "
+		if i == defaultIndex {
+			marker = "*"
+		}
+		command := profile.Command
+		if command == "" {
+			command = project.Command
+		}
+		line := fmt.Sprintf("%s %-16s %s", marker, runewidth.Truncate(profile.Name, 16, "…"), command)
+		if profile.Dir != "" {
+			line += "  in " + profile.Dir
+		}
+		if len(profile.Env) > 0 {
+			line += fmt.Sprintf("  (%d env vars)", len(profile.Env))
+		}
+		line = runewidth.Truncate(line, width, "…")
+		if i == m.profiles.cursor {
+			line = selectedStyle.Render(line)
+		}
+		rows = append(rows, line)
+	}
+
+	hints := renderKeyHints([]keyHint{
+		{"↑↓", "select"},
+		{"enter", "launch"},
+		{"*", "make default"},
+		{"esc", "cancel"},
+	})
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n%s", header, strings.Join(rows, "\n"), hints, statusMessage)
+}
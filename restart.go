@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RestartPolicy says when the launcher relaunches a project that exited on
+// its own while the launcher is running
+type RestartPolicy struct {
+	Policy            string `json:"policy"`                        // never, on-failure or always
+	MaxRestarts       int    `json:"max_restarts,omitempty"`        // Within window_seconds, before giving up
+	WindowSeconds     int    `json:"window_seconds,omitempty"`      // How far back restarts are counted
+	BackoffSeconds    int    `json:"backoff_seconds,omitempty"`     // Delay before the first restart, doubled for each further one
+	MaxBackoffSeconds int    `json:"max_backoff_seconds,omitempty"` // Cap on the delay
+}
+
+const (
+	defaultMaxRestarts    = 5
+	defaultRestartWindow  = time.Minute
+	defaultRestartBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	crashTailLines        = 8
+)
+
+// restartState is the restart history of a project. It outlives the
+// processInfo of each launch so restarts can be counted across them.
+type restartState struct {
+	restarts []time.Time
+	pending  bool // The next launch is an automatic restart
+}
+
+// restartDueMsg fires when the backoff before a restart has elapsed
+type restartDueMsg struct {
+	key string
+	pid int // The process that exited
+}
+
+// restarts reports whether the policy relaunches after an exit with code
+func (p RestartPolicy) restarts(code int) bool {
+	switch p.Policy {
+	case "always":
+		return true
+	case "on-failure":
+		return code != 0
+	}
+	return false
+}
+
+func (p RestartPolicy) maxRestarts() int {
+	if p.MaxRestarts <= 0 {
+		return defaultMaxRestarts
+	}
+	return p.MaxRestarts
+}
+
+func (p RestartPolicy) window() time.Duration {
+	if p.WindowSeconds <= 0 {
+		return defaultRestartWindow
+	}
+	return time.Duration(p.WindowSeconds) * time.Second
+}
+
+// backoff is the delay before the restart that follows n recent ones
+func (p RestartPolicy) backoff(n int) time.Duration {
+	delay, limit := defaultRestartBackoff, defaultMaxBackoff
+	if p.BackoffSeconds > 0 {
+		delay = time.Duration(p.BackoffSeconds) * time.Second
+	}
+	if p.MaxBackoffSeconds > 0 {
+		limit = time.Duration(p.MaxBackoffSeconds) * time.Second
+	}
+	for ; n > 0 && delay < limit; n-- {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
+
+// planRestart applies policy to a process that just exited. It returns the
+// delay before relaunching it, or crashLoop when the process restarted too
+// often within the window and the launcher gives up on it.
+func (r *processRegistry) planRestart(key string, policy RestartPolicy) (delay time.Duration, restart, crashLoop bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[key]
+	if !ok || info.State == procRunning || info.State == procStopped || !policy.restarts(info.ExitCode) {
+		return 0, false, false
+	}
+
+	state := r.restarts[key]
+	if state == nil {
+		state = &restartState{}
+		r.restarts[key] = state
+	}
+	cutoff := time.Now().Add(-policy.window())
+	recent := state.restarts[:0]
+	for _, at := range state.restarts {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	state.restarts = recent
+
+	if len(recent) >= policy.maxRestarts() {
+		info.CrashLoop = true
+		info.CrashTail = launchTail(info.LogPath, crashTailLines)
+		return 0, false, true
+	}
+	delay = policy.backoff(len(recent))
+	state.restarts = append(state.restarts, time.Now())
+	state.pending = true
+	info.RestartAt = time.Now().Add(delay)
+	return delay, true, false
+}
+
+// takeRestart reports whether the restart scheduled after pid exited is still
+// wanted, i.e. the project was neither relaunched nor stopped in the meantime
+func (r *processRegistry) takeRestart(msg restartDueMsg) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[msg.key]
+	if !ok || info.PID != msg.pid || info.RestartAt.IsZero() {
+		return false
+	}
+	info.RestartAt = time.Time{}
+	return true
+}
+
+// cancelRestart drops a scheduled restart and the crash loop state
+func (r *processRegistry) cancelRestart(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.procs[key]
+	if !ok || info.State == procRunning || (info.RestartAt.IsZero() && !info.CrashLoop) {
+		return false
+	}
+	info.RestartAt = time.Time{}
+	info.CrashLoop = false
+	info.CrashTail = nil
+	info.State = procStopped
+	delete(r.restarts, key)
+	return true
+}
+
+// scheduleRestart relaunches a project that exited on its own when its
+// restart policy asks for it
+func (m model) scheduleRestart(project Project, msg processExitedMsg) tea.Cmd {
+	if project.Restart == nil {
+		return nil
+	}
+	info, _ := m.processes.get(msg.key)
+	if resolved, err := project.withProfile(info.Profile); err == nil && resolved.startsDetached() {
+		return nil // Only the Start-Process wrapper exited, not the program
+	}
+	delay, restart, crashLoop := m.processes.planRestart(msg.key, *project.Restart)
+	switch {
+	case crashLoop:
+		return showStatus(fmt.Sprintf("❌ %s is crash looping (exit code %d), gave up after %d restarts",
+			project.Name, msg.exitCode, project.Restart.maxRestarts()))
+	case restart:
+		return tea.Batch(
+			showStatus(fmt.Sprintf("🔁 %s exited with code %d, restarting in %s", project.Name, msg.exitCode, delay)),
+			tea.Tick(delay, func(time.Time) tea.Msg { return restartDueMsg{key: msg.key, pid: msg.pid} }),
+		)
+	}
+	return nil
+}
+
+// crashNotice explains why the selected project is in a crash loop, with the
+// tail of its last launch's output
+func (m model) crashNotice(project Project) string {
+	info, ok := m.processes.get(processKey(project))
+	if !ok || !info.CrashLoop {
+		return ""
+	}
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("🔁 %s keeps crashing: gave up after exit code %d", project.Name, info.ExitCode))}
+	for _, line := range info.CrashTail {
+		lines = append(lines, dimStyle.Render("   "+line))
+	}
+	lines = append(lines, renderKeyHints([]keyHint{{"l", "full log"}, {"space", "launch again"}, {"s", "dismiss"}}))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestExternalStopIsNotRestarted(t *testing.T) {
+	tests := []struct {
+		name        string
+		stopping    bool // project-launcher stop marked the run record
+		wantState   processState
+		wantRestart bool
+	}{
+		{"crash", false, procCrashed, true},
+		{"stopped by the CLI", true, procStopped, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_STATE_HOME", t.TempDir())
+			project := Project{ID: "p1", Name: "API", Path: t.TempDir(), Command: "true",
+				Restart: &RestartPolicy{Policy: "always"}}
+			m := newTestModel([]Project{project})
+
+			cmd := exec.Command("sleep", "30")
+			if err := cmd.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer cmd.Process.Kill()
+			key, pid := processKey(project), cmd.Process.Pid
+			m.processes.track(key, "", cmd, nil)
+			writeRunRecord(project, pid, nil)
+			if tt.stopping {
+				record, _ := readRunRecord(key)
+				record.Stopping = true
+				saveRunRecord(key, record)
+			}
+
+			updated, _ := m.Update(processExitedMsg{key: key, pid: pid, exitCode: 143, signaled: true})
+			info, _ := updated.(model).processes.get(key)
+			if info.State != tt.wantState {
+				t.Errorf("state = %v, want %v", info.State, tt.wantState)
+			}
+			if restart := !info.RestartAt.IsZero(); restart != tt.wantRestart {
+				t.Errorf("restart scheduled = %v, want %v", restart, tt.wantRestart)
+			}
+			if _, ok := readRunRecord(key); ok {
+				t.Error("run record left behind")
+			}
+		})
+	}
+}
+
+func TestStopLeavesRecordToItsLauncher(t *testing.T) {
+	owner := exec.Command("sleep", "30")
+	if err := owner.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer owner.Process.Kill()
+
+	tests := []struct {
+		name       string
+		launcher   int
+		wantRecord bool
+	}{
+		{"launcher still running", owner.Process.Pid, true},
+		{"launcher gone", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := Project{ID: "p1", Name: "API", Path: t.TempDir(), Command: "sleep 30"}
+			c, _ := newTestCLI(t, []Project{project})
+			target := exec.Command("sleep", "30")
+			if err := target.Start(); err != nil {
+				t.Fatal(err)
+			}
+			go target.Wait()
+			record := runRecord{Name: project.Name, PID: target.Process.Pid, Launcher: tt.launcher}
+			saveRunRecord(processKey(project), record)
+
+			if _, err := c.stopRecord(project, record); err != nil {
+				t.Fatal(err)
+			}
+			left, ok := readRunRecord(processKey(project))
+			if ok != tt.wantRecord {
+				t.Fatalf("record left = %v, want %v", ok, tt.wantRecord)
+			}
+			if ok && !left.Stopping {
+				t.Error("record not marked as stopping")
+			}
+		})
+	}
+}
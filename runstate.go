@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// runRecord is the on-disk trace of a launch, so a launcher instance (or the
+// CLI) can find processes started by another one
+type runRecord struct {
+	Name      string    `json:"name"`
+	PID       int       `json:"pid"`
+	PGID      int       `json:"pgid"`
+	StartedAt time.Time `json:"started_at"`
+	Command   string    `json:"command"`
+	Profile   string    `json:"profile,omitempty"`
+	LogPath   string    `json:"log_path,omitempty"`
+	Launcher  int       `json:"launcher_pid,omitempty"` // Process that waits for it and removes the record
+	Stopping  bool      `json:"stopping,omitempty"`     // Set by project-launcher stop, so the exit is not a crash
+}
+
+func runRecordPath(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(stateDir(), "run", hex.EncodeToString(sum[:8])+".json")
+}
+
+// writeRunRecord records a freshly started launch. Failures are ignored: the
+// record is a convenience for other instances, not needed by this one.
+func writeRunRecord(project Project, pid int, logs *launchLog) {
+	record := runRecord{
+		Name:      project.Name,
+		PID:       pid,
+		StartedAt: time.Now(),
+		Command:   project.Command,
+		Profile:   project.profile,
+	}
+	if pgid, err := syscall.Getpgid(pid); err == nil && pgid != syscall.Getpgrp() {
+		record.PGID = pgid
+	}
+	if logs != nil {
+		record.LogPath = logs.path
+	}
+	record.Launcher = os.Getpid()
+	saveRunRecord(processKey(project), record)
+}
+
+func saveRunRecord(key string, record runRecord) error {
+	path := runRecordPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func readRunRecord(key string) (runRecord, bool) {
+	var record runRecord
+	data, err := os.ReadFile(runRecordPath(key))
+	if err != nil {
+		return record, false
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return record, false
+	}
+	return record, true
+}
+
+// removeRunRecord deletes the record for key if it still belongs to pid
+func removeRunRecord(key string, pid int) {
+	if record, ok := readRunRecord(key); ok && record.PID == pid {
+		os.Remove(runRecordPath(key))
+	}
+}
+
+// alive reports whether the recorded process still exists
+func (r runRecord) alive() bool {
+	return processAlive(r.PID)
+}
+
+// ownerAlive reports whether the launcher waiting for the process is still
+// there to record its exit and remove the record
+func (r runRecord) ownerAlive() bool {
+	return r.Launcher != os.Getpid() && processAlive(r.Launcher)
+}
+
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return (err == nil || err == syscall.EPERM) && !zombie(pid)
+}
+
+// zombie reports whether pid has exited but not been reaped yet, which
+// happens to children of a CLI command that is still running, or under an
+// init that doesn't reap orphans
+func zombie(pid int) bool {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	// The state follows the parenthesised command name, which may contain spaces
+	end := bytes.LastIndexByte(data, ')')
+	return end != -1 && end+2 < len(data) && data[end+2] == 'Z'
+}
+
+func (r runRecord) signal(sig syscall.Signal) error {
+	if r.PGID > 0 {
+		return syscall.Kill(-r.PGID, sig)
+	}
+	return syscall.Kill(r.PID, sig)
+}
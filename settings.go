@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Settings holds launcher-wide options stored next to the project list
+type Settings struct {
+	StopGraceSeconds int    `json:"stop_grace_seconds,omitempty"`
+	LogDir           string `json:"log_dir,omitempty"`
+	LogMaxBytes      int64  `json:"log_max_bytes,omitempty"`
+	LogRetention     int    `json:"log_retention,omitempty"`
+	HistoryCount     *int   `json:"history_count,omitempty"`
+	EarlyExitSeconds int    `json:"early_exit_seconds,omitempty"`
+	Shell            string `json:"shell,omitempty"`    // Opened in project directories, $SHELL when empty
+	Editor           string `json:"editor,omitempty"`   // Opens project directories, before $VISUAL and $EDITOR
+	Terminal         string `json:"terminal,omitempty"` // Emulator command for the terminal launch target
+
+	DiscoveryRoots  []string `json:"discovery_roots,omitempty"`
+	DiscoveryDepth  int      `json:"discovery_depth,omitempty"`
+	DiscoveryIgnore []string `json:"discovery_ignore,omitempty"`
+
+	Groups []Group `json:"groups,omitempty"`
+}
+
+const (
+	defaultStopGrace      = 5 * time.Second
+	defaultLogMaxBytes    = 5 << 20
+	defaultLogRetention   = 3
+	defaultHistoryCount   = 50
+	defaultDiscoveryDepth = 3
+	defaultEarlyExit      = 3 * time.Second
+)
+
+// defaultDiscoveryRoots are scanned when no discovery_roots are configured,
+// relative to the home directory
+var defaultDiscoveryRoots = []string{"code", "projects", "dev", "src", "repos"}
+
+// defaultDiscoveryIgnore skips dependency, build and hidden directories
+var defaultDiscoveryIgnore = []string{
+	".*", "node_modules", "vendor", "target", "dist", "build", "venv", "__pycache__",
+}
+
+func settingsFileFor(configFile string) string {
+	return filepath.Join(filepath.Dir(configFile), "settings.json")
+}
+
+// loadSettings reads the settings file. A missing file means defaults; one
+// that doesn't parse is reported as a *configError, with defaults returned.
+func loadSettings(settingsFile string) (Settings, error) {
+	var settings Settings
+	data, err := os.ReadFile(settingsFile)
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return settings, nil
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return Settings{}, newConfigError(settingsFile, data, err)
+	}
+	return settings, nil
+}
+
+// stopGrace is how long a stopped process gets between SIGTERM and SIGKILL
+func (s Settings) stopGrace() time.Duration {
+	if s.StopGraceSeconds <= 0 {
+		return defaultStopGrace
+	}
+	return time.Duration(s.StopGraceSeconds) * time.Second
+}
+
+// earlyExitWindow is how soon after launch a failing exit counts as a failed
+// launch rather than a crash
+func (s Settings) earlyExitWindow() time.Duration {
+	if s.EarlyExitSeconds <= 0 {
+		return defaultEarlyExit
+	}
+	return time.Duration(s.EarlyExitSeconds) * time.Second
+}
+
+// historyCount is how many config snapshots are kept; 0 turns history off
+func (s Settings) historyCount() int {
+	if s.HistoryCount == nil || *s.HistoryCount < 0 {
+		return defaultHistoryCount
+	}
+	return *s.HistoryCount
+}
+
+// logMaxBytes is the size at which a project log is rotated
+func (s Settings) logMaxBytes() int64 {
+	if s.LogMaxBytes <= 0 {
+		return defaultLogMaxBytes
+	}
+	return s.LogMaxBytes
+}
+
+// logRetention is how many rotated logs are kept per project
+func (s Settings) logRetention() int {
+	if s.LogRetention <= 0 {
+		return defaultLogRetention
+	}
+	return s.LogRetention
+}
+
+// discoveryRoots are the directories scanned for new projects
+func (s Settings) discoveryRoots() []string {
+	if len(s.DiscoveryRoots) > 0 {
+		roots := make([]string, len(s.DiscoveryRoots))
+		for i, root := range s.DiscoveryRoots {
+			roots[i] = expandHome(root)
+		}
+		return roots
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	var roots []string
+	for _, name := range defaultDiscoveryRoots {
+		root := filepath.Join(home, name)
+		if info, err := os.Stat(root); err == nil && info.IsDir() {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// discoveryDepth is how many directory levels below each root are scanned
+func (s Settings) discoveryDepth() int {
+	if s.DiscoveryDepth <= 0 {
+		return defaultDiscoveryDepth
+	}
+	return s.DiscoveryDepth
+}
+
+// discoveryIgnore are the globs of directories skipped while scanning
+func (s Settings) discoveryIgnore() []string {
+	if s.DiscoveryIgnore != nil {
+		return s.DiscoveryIgnore
+	}
+	return defaultDiscoveryIgnore
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    *string
+		wantErr    bool
+		wantLine   int
+		wantGroups int
+	}{
+		{"missing file", nil, false, 0, 0},
+		{"empty file", ptr("  \n"), false, 0, 0},
+		{"valid", ptr(`{"groups": [{"name": "web", "projects": [{"project": "API"}]}]}`), false, 0, 1},
+		{"syntax error", ptr("{\n  \"groups\": [\n    {\"name\": \"web\" \"projects\": []}\n  ]\n}\n"), true, 3, 0},
+		{"wrong type", ptr("{\n  \"stop_grace_seconds\": \"ten\"\n}\n"), true, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "settings.json")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			settings, err := loadSettings(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadSettings error = %v, want error %v", err, tt.wantErr)
+			}
+			var cfgErr *configError
+			if tt.wantErr && (!errors.As(err, &cfgErr) || cfgErr.Line != tt.wantLine) {
+				t.Errorf("error %v, want a configError on line %d", err, tt.wantLine)
+			}
+			if len(settings.Groups) != tt.wantGroups {
+				t.Errorf("%d groups, want %d", len(settings.Groups), tt.wantGroups)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string { return &s }
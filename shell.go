@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// shellExitedMsg reports the end of a shell opened from the launcher
+type shellExitedMsg struct {
+	name string
+	err  error
+}
+
+// shellCommand is the shell opened in project directories: the configured
+// one, then $SHELL, then sh
+func (s Settings) shellCommand() []string {
+	if fields := strings.Fields(s.Shell); len(fields) > 0 {
+		return fields
+	}
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return []string{shell}
+	}
+	return []string{"/bin/sh"}
+}
+
+// openShell suspends the launcher and starts a shell in the project
+// directory with the environment of its default profile
+func (m model) openShell(project Project) tea.Cmd {
+	resolved, err := project.withProfile("")
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ %v", err))
+	}
+	env, err := launchEnv(resolved)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ Cannot build environment: %v", err))
+	}
+	if info, err := os.Stat(project.Path); err != nil || !info.IsDir() {
+		return showStatus(fmt.Sprintf("❌ %s is not a directory", project.Path))
+	}
+
+	shell := m.settings.shellCommand()
+	cmd := exec.Command(shell[0], shell[1:]...)
+	cmd.Dir = project.Path
+	cmd.Env = environ(env)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		// The exit status of an interactive shell is whatever ran last
+		if cmd.ProcessState != nil {
+			err = nil
+		}
+		return shellExitedMsg{name: project.Name, err: err}
+	})
+}
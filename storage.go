@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// errConfigChanged is returned when the config file was modified by someone
+// else since it was last loaded
+var errConfigChanged = errors.New("config changed on disk since it was loaded, press r to reload")
+
+// configError describes a config file that exists but cannot be parsed
+type configError struct {
+	Path   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *configError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %v", e.Path, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *configError) Unwrap() error { return e.Err }
+
+// projectStore reads and writes the project list. Writes are atomic, guarded
+// by an advisory lock, and refused when the file changed underneath us or
+// cannot be parsed, so a typo in a hand edit is never silently overwritten.
+type projectStore struct {
+	path    string
+	modTime time.Time // Modification time seen at the last load or save
+	exists  bool      // Whether the file existed at the last load or save
+
+	historyLimit int  // Number of snapshots kept, 0 disables history
+	session      bool // Saves are grouped, e.g. the fields of one edit
+	sessionSaved bool // The group's snapshot has been taken
+}
+
+func newProjectStore(path string, historyLimit int) *projectStore {
+	return &projectStore{path: path, historyLimit: historyLimit}
+}
+
+// Load reads the project list. A missing file is an empty list; a file that
+// doesn't parse is reported as a *configError with line and column.
+func (s *projectStore) Load() ([]Project, error) {
+	var projects []Project
+	err := s.withLock(syscall.LOCK_SH, func() error {
+		info, err := os.Stat(s.path)
+		if errors.Is(err, os.ErrNotExist) {
+			s.exists = false
+			s.modTime = time.Time{}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		projects, err = s.parse()
+		if err != nil {
+			return err
+		}
+		s.exists = true
+		s.modTime = info.ModTime()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Persist back-filled IDs so every instance agrees on them
+	if ensureProjectIDs(projects) {
+		if err := s.Save(projects); err != nil {
+			return projects, err
+		}
+	}
+	return projects, nil
+}
+
+// parse reads and decodes the file at s.path
+func (s *projectStore) parse() ([]Project, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []Project
+	if len(bytes.TrimSpace(data)) == 0 {
+		return projects, nil
+	}
+	if err := json.Unmarshal(data, &projects); err != nil {
+		return nil, newConfigError(s.path, data, err)
+	}
+	return projects, nil
+}
+
+// newConfigError locates a JSON decoding error of the file at path
+func newConfigError(path string, data []byte, err error) *configError {
+	cfgErr := &configError{Path: path, Err: err}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		// The offset counts the offending byte as read
+		cfgErr.Line, cfgErr.Column = lineColumn(data, max(syntaxErr.Offset-1, 0))
+	case errors.As(err, &typeErr):
+		cfgErr.Line, cfgErr.Column = lineColumn(data, typeErr.Offset)
+	}
+	return cfgErr
+}
+
+// lineColumn converts a byte offset into 1-based line and column numbers
+func lineColumn(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// Save atomically replaces the project list
+func (s *projectStore) Save(projects []Project) error {
+	data, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	return s.withLock(syscall.LOCK_EX, func() error {
+		info, err := os.Stat(s.path)
+		switch {
+		case err == nil:
+			// Never replace a file we can't read back, e.g. one hand-edited into a broken state
+			if _, err := s.parse(); err != nil {
+				return err
+			}
+			if !s.exists || !info.ModTime().Equal(s.modTime) {
+				return errConfigChanged
+			}
+		case errors.Is(err, os.ErrNotExist):
+			if s.exists {
+				return errConfigChanged
+			}
+		default:
+			return err
+		}
+
+		if err := s.snapshotCurrent(); err != nil {
+			return fmt.Errorf("saving history snapshot: %w", err)
+		}
+		if err := writeFileAtomic(s.path, data, 0644); err != nil {
+			return err
+		}
+		if info, err := os.Stat(s.path); err == nil {
+			s.exists = true
+			s.modTime = info.ModTime()
+		}
+		return nil
+	})
+}
+
+// withLock runs fn holding an advisory lock on a sidecar lock file. File
+// systems without flock support (e.g. some Windows mounts) run fn unlocked.
+func (s *projectStore) withLock(how int, fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	lock, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+
+	for {
+		err = syscall.Flock(int(lock.Fd()), how)
+		if err != syscall.EINTR {
+			break
+		}
+	}
+	switch err {
+	case nil:
+		defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+	case syscall.ENOTSUP, syscall.ENOLCK, syscall.EINVAL, syscall.ENOSYS:
+	default:
+		return fmt.Errorf("locking %s: %w", s.path, err)
+	}
+	return fn()
+}
+
+// writeFileAtomic writes data to a temporary file next to path, syncs it and
+// renames it into place so readers never see a partial file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return err
+	}
+
+	// Sync the directory so the rename itself is durable
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProjectStoreLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  *string // nil leaves the file missing
+		wantLen  int
+		wantLine int // Line of the reported *configError, 0 for none
+		wantCol  int
+	}{
+		{"missing file", nil, 0, 0, 0},
+		{"empty file", ptr("  \n"), 0, 0, 0},
+		{"projects", ptr(`[{"id": "a", "name": "API"}, {"id": "b", "name": "Web"}]`), 2, 0, 0},
+		{"syntax error", ptr("[\n  {\"name\": \"API\",}\n]"), 0, 2, 18},
+		{"type error", ptr("[\n  {\"name\": 42}\n]"), 0, 2, 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			projects, err := newProjectStore(path, 0).Load()
+			var cfgErr *configError
+			if tt.wantLine > 0 {
+				if !errors.As(err, &cfgErr) {
+					t.Fatalf("error = %v, want a *configError", err)
+				}
+				if cfgErr.Line != tt.wantLine || cfgErr.Column != tt.wantCol {
+					t.Errorf("error at %d:%d, want %d:%d", cfgErr.Line, cfgErr.Column, tt.wantLine, tt.wantCol)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(projects) != tt.wantLen {
+				t.Errorf("loaded %d projects, want %d", len(projects), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestProjectStoreLoadPersistsIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`[{"name": "API"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	first, err := newProjectStore(path, 0).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := newProjectStore(path, 0).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first[0].ID == "" || first[0].ID != second[0].ID {
+		t.Errorf("IDs %q and %q, want the same back-filled ID", first[0].ID, second[0].ID)
+	}
+}
+
+func TestProjectStoreSave(t *testing.T) {
+	projects := []Project{{ID: "a", Name: "API"}}
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, path string, store *projectStore)
+		wantErr error // nil for success, errConfigChanged, or errAny for any error
+	}{
+		{"creates the parent directory", func(t *testing.T, path string, store *projectStore) {}, nil},
+		{"after a load", func(t *testing.T, path string, store *projectStore) {
+			writeConfig(t, path, `[]`)
+			mustLoad(t, store)
+		}, nil},
+		{"changed on disk", func(t *testing.T, path string, store *projectStore) {
+			writeConfig(t, path, `[]`)
+			mustLoad(t, store)
+			writeConfig(t, path, `[{"id": "x", "name": "Other"}]`)
+			os.Chtimes(path, time.Now(), time.Now().Add(time.Minute))
+		}, errConfigChanged},
+		{"created by someone else", func(t *testing.T, path string, store *projectStore) {
+			mustLoad(t, store)
+			writeConfig(t, path, `[]`)
+		}, errConfigChanged},
+		{"deleted by someone else", func(t *testing.T, path string, store *projectStore) {
+			writeConfig(t, path, `[]`)
+			mustLoad(t, store)
+			os.Remove(path)
+		}, errConfigChanged},
+		{"unparseable file", func(t *testing.T, path string, store *projectStore) {
+			writeConfig(t, path, `[{"name": }]`)
+			store.Load()
+		}, errAny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "nested", "dir", "config.json")
+			store := newProjectStore(path, 0)
+			tt.prepare(t, path, store)
+			before, _ := os.ReadFile(path)
+
+			err := store.Save(projects)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Save: %v", err)
+			case tt.wantErr == errAny && err == nil, tt.wantErr == errConfigChanged && !errors.Is(err, errConfigChanged):
+				t.Fatalf("Save error = %v, want %v", err, tt.wantErr)
+			}
+
+			after, _ := os.ReadFile(path)
+			if tt.wantErr != nil {
+				if string(after) != string(before) {
+					t.Errorf("refused save changed the file to %q", after)
+				}
+				return
+			}
+			loaded, err := newProjectStore(path, 0).Load()
+			if err != nil || len(loaded) != 1 || loaded[0].Name != "API" {
+				t.Errorf("saved file loads as %v, %v", loaded, err)
+			}
+			if err := store.Save(projects); err != nil {
+				t.Errorf("second save: %v", err)
+			}
+		})
+	}
+}
+
+// errAny stands for any error in test tables
+var errAny = errors.New("any error")
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustLoad(t *testing.T, store *projectStore) {
+	t.Helper()
+	if _, err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+}
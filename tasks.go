@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Task is a named command run in a project's directory, besides its main
+// launch command
+type Task struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Source  string `json:"-"` // Where the task was found, "config" for configured tasks
+}
+
+// taskProvider finds the tasks a kind of project file defines in dir
+type taskProvider func(dir string) []Task
+
+// taskProviders are asked for tasks in this order; the first task of a
+// given name wins
+var taskProviders = []taskProvider{
+	packageJSONTasks,
+	makefileTasks,
+	justfileTasks,
+	taskfileTasks,
+}
+
+// projectTasks lists the configured tasks followed by the ones found in the
+// project's files. Configured tasks override found ones of the same name.
+func projectTasks(project Project) []Task {
+	var tasks []Task
+	seen := make(map[string]bool)
+	for _, task := range project.Tasks {
+		task.Source = "config"
+		seen[task.Name] = true
+		tasks = append(tasks, task)
+	}
+	dir := expandHome(project.Path)
+	for _, provide := range taskProviders {
+		for _, task := range provide(dir) {
+			if !seen[task.Name] {
+				seen[task.Name] = true
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return tasks
+}
+
+func packageJSONTasks(dir string) []Task {
+	scripts, _ := packageScripts(dir)
+	manager := nodePackageManager(dir)
+	var tasks []Task
+	for _, script := range scripts {
+		tasks = append(tasks, Task{Name: script, Command: runScriptCommand(manager, script), Source: "package.json"})
+	}
+	return tasks
+}
+
+func makefileTasks(dir string) []Task {
+	targets, _ := makefileTargets(dir)
+	var tasks []Task
+	for _, target := range targets {
+		tasks = append(tasks, Task{Name: target, Command: "make " + target, Source: "Makefile"})
+	}
+	return tasks
+}
+
+// justRecipe matches a recipe header such as "build target='x': deps".
+// Assignments, settings and aliases are told apart by their :=
+var justRecipe = regexp.MustCompile(`^@?([A-Za-z][A-Za-z0-9_-]*)\b[^:]*:`)
+
+func justfileTasks(dir string) []Task {
+	var data []byte
+	var err error
+	for _, name := range []string{"justfile", "Justfile", ".justfile"} {
+		if data, err = os.ReadFile(filepath.Join(dir, name)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil
+	}
+
+	var tasks []Task
+	private := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "[") {
+			// Attributes apply to the recipe that follows
+			private = private || strings.Contains(line, "private")
+			continue
+		}
+		match := justRecipe.FindStringSubmatch(line)
+		if match == nil || strings.Contains(line, ":=") {
+			continue
+		}
+		if !private {
+			tasks = append(tasks, Task{Name: match[1], Command: "just " + match[1], Source: "justfile"})
+		}
+		private = false
+	}
+	return tasks
+}
+
+// taskfileKey matches a mapping key on its own line, e.g. "  build:"
+var taskfileKey = regexp.MustCompile(`^(\s+)([A-Za-z0-9_:.-]+|"[^"]+"|'[^']+'):\s*(#.*)?$`)
+
+// taskfileTasks reads the task names under the top-level tasks: key of a
+// Taskfile. Only block-style YAML is understood, which is what Taskfiles use.
+func taskfileTasks(dir string) []Task {
+	var data []byte
+	var err error
+	for _, name := range []string{"Taskfile.yml", "Taskfile.yaml", "taskfile.yml", "taskfile.yaml"} {
+		if data, err = os.ReadFile(filepath.Join(dir, name)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil
+	}
+
+	var tasks []Task
+	inTasks := false
+	indent := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			inTasks = strings.HasPrefix(line, "tasks:")
+			indent = ""
+			continue
+		}
+		if !inTasks {
+			continue
+		}
+		match := taskfileKey.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		// Task names sit at the first indentation level below tasks:
+		if indent == "" {
+			indent = match[1]
+		}
+		if match[1] != indent {
+			continue
+		}
+		name := strings.Trim(match[2], `"'`)
+		tasks = append(tasks, Task{Name: name, Command: "task " + name, Source: "Taskfile"})
+	}
+	return tasks
+}
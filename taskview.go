@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// taskOutputLimit is how much of a task's output is kept, the tail wins
+const taskOutputLimit = 256 << 10
+
+// taskOutput collects a running task's stdout and stderr
+type taskOutput struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (o *taskOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.buf = append(o.buf, p...)
+	if len(o.buf) > taskOutputLimit {
+		o.buf = o.buf[len(o.buf)-taskOutputLimit:]
+	}
+	return len(p), nil
+}
+
+func (o *taskOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return string(bytes.ToValidUTF8(o.buf, nil))
+}
+
+// taskRun is one run of a task, shared between the view and its reaper
+type taskRun struct {
+	task      Task
+	cmd       *exec.Cmd
+	output    *taskOutput
+	startedAt time.Time
+	duration  time.Duration
+	done      bool
+	exitCode  int
+	err       error // Set when the task could not be started
+}
+
+// taskFinishedMsg is sent when a task's process exits
+type taskFinishedMsg struct {
+	run      *taskRun
+	exitCode int
+}
+
+// taskView lists a project's tasks and shows the output of the last run
+type taskView struct {
+	project Project
+	tasks   []Task
+	cursor  int
+	run     *taskRun
+	output  viewport.Model
+}
+
+// openTasks shows the task menu for project
+func (m *model) openTasks(project Project) tea.Cmd {
+	tasks := projectTasks(project)
+	if len(tasks) == 0 {
+		return showStatus(fmt.Sprintf("🧰 No tasks found for %s", project.Name))
+	}
+	m.tasks = taskView{
+		project: project,
+		tasks:   tasks,
+		output:  viewport.New(m.width, m.taskOutputHeight()),
+	}
+	m.taskMode = true
+	return nil
+}
+
+func (m *model) closeTasks() {
+	m.taskMode = false
+	m.tasks = taskView{}
+}
+
+// taskListHeight is how many task rows are shown above the output
+func (m *model) taskListHeight() int {
+	return min(len(m.tasks.tasks), max(m.height/3, 3))
+}
+
+func (m *model) taskOutputHeight() int {
+	return max(m.height-m.taskListHeight()-7, 3)
+}
+
+// runTask starts the selected task in the project directory, capturing its
+// output
+func (m *model) runTask() tea.Cmd {
+	v := &m.tasks
+	if v.run != nil && !v.run.done {
+		return showStatus("⏳ A task is already running, x stops it")
+	}
+	task := v.tasks[v.cursor]
+	project := v.project.withCommand(task.Command)
+	cmd, _, err := buildLaunchCommand(project)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ Failed to run %s: %v", task.Name, err))
+	}
+
+	run := &taskRun{task: task, cmd: cmd, output: &taskOutput{}, startedAt: time.Now()}
+	cmd.Stdout = run.output
+	cmd.Stderr = run.output
+	v.run = run
+	v.output.SetContent("")
+	if err := cmd.Start(); err != nil {
+		run.done = true
+		run.err = err
+		return showStatus(fmt.Sprintf("❌ Failed to run %s: %v", task.Name, err))
+	}
+	return func() tea.Msg {
+		code, _ := exitStatus(cmd.Wait())
+		return taskFinishedMsg{run: run, exitCode: code}
+	}
+}
+
+// stopTask terminates the running task's process group
+func (m *model) stopTask() tea.Cmd {
+	run := m.tasks.run
+	if run == nil || run.done || run.cmd.Process == nil {
+		return nil
+	}
+	pid := run.cmd.Process.Pid
+	if err := syscall.Kill(-pid, syscall.SIGTERM); err != nil {
+		run.cmd.Process.Signal(syscall.SIGTERM)
+	}
+	return showStatus(fmt.Sprintf("⏹️ Stopping %s", run.task.Name))
+}
+
+// finishTask records a task's exit and shows its final output
+func (m *model) finishTask(msg taskFinishedMsg) tea.Cmd {
+	run := msg.run
+	run.done = true
+	run.exitCode = msg.exitCode
+	run.duration = time.Since(run.startedAt)
+	if !m.taskMode || m.tasks.run != run {
+		// The menu was closed while the task ran
+		if run.exitCode != 0 {
+			return showStatus(fmt.Sprintf("❌ Task %s failed (exit %d)", run.task.Name, run.exitCode))
+		}
+		return showStatus(fmt.Sprintf("✅ Task %s finished", run.task.Name))
+	}
+	m.refreshTaskOutput()
+	return nil
+}
+
+// refreshTaskOutput copies the captured output into the viewport, staying
+// at the bottom unless the user scrolled up
+func (m *model) refreshTaskOutput() {
+	v := &m.tasks
+	if v.run == nil {
+		return
+	}
+	follow := v.output.AtBottom()
+	v.output.SetContent(v.run.output.String())
+	if follow {
+		v.output.GotoBottom()
+	}
+}
+
+func (m model) updateTasks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.tasks
+	switch msg.String() {
+	case "q", "esc":
+		m.closeTasks()
+		return m, nil
+	case "up", "k":
+		v.cursor = max(v.cursor-1, 0)
+		return m, nil
+	case "down", "j":
+		v.cursor = min(v.cursor+1, len(v.tasks)-1)
+		return m, nil
+	case "enter", " ":
+		return m, m.runTask()
+	case "x":
+		return m, m.stopTask()
+	}
+
+	var cmd tea.Cmd
+	v.output, cmd = v.output.Update(msg)
+	return m, cmd
+}
+
+func (m model) viewTasks(statusMessage string) string {
+	v := m.tasks
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86")).
+		Render("🧰 Tasks: " + v.project.Name)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+
+	var rows []string
+	width := max(m.width-2, 40)
+	start, end := visibleWindow(v.cursor, len(v.tasks), m.taskListHeight())
+	for i := start; i < end; i++ {
+		task := v.tasks[i]
+		line := fmt.Sprintf("%-20s %-12s %s",
+			runewidth.Truncate(task.Name, 20, "…"),
+			task.Source,
+			task.Command)
+		line = runewidth.Truncate(line, width, "…")
+		if i == v.cursor {
+			line = selectedStyle.Render(line)
+		}
+		rows = append(rows, line)
+	}
+
+	var state string
+	switch run := v.run; {
+	case run == nil:
+		state = dimStyle.Render("Select a task and press enter to run it")
+	case run.err != nil:
+		state = fmt.Sprintf("❌ %s could not start: %v", run.task.Name, run.err)
+	case !run.done:
+		state = fmt.Sprintf("⏳ %s running for %s", run.task.Name, formatUptime(time.Since(run.startedAt)))
+	case run.exitCode == 0:
+		state = fmt.Sprintf("✅ %s finished in %s", run.task.Name, run.duration.Round(100*time.Millisecond))
+	default:
+		state = fmt.Sprintf("❌ %s failed with exit code %d after %s", run.task.Name, run.exitCode, run.duration.Round(100*time.Millisecond))
+	}
+
+	hints := []keyHint{{"↑↓", "select"}, {"enter", "run"}}
+	if v.run != nil && !v.run.done {
+		hints = append(hints, keyHint{"x", "stop"})
+	}
+	hints = append(hints, keyHint{"pgup/pgdn", "scroll output"}, keyHint{"esc", "back"})
+
+	return fmt.Sprintf("%s\n%s\n\n%s\n%s\n%s\n%s",
+		header,
+		strings.Join(rows, "\n"),
+		state,
+		v.output.View(),
+		renderKeyHints(hints),
+		statusMessage)
+}
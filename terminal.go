@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Launch targets that open a project in a terminal of its own instead of
+// running it in the background
+const (
+	targetTmux     = "tmux"     // A tmux window, or a session outside tmux
+	targetWT       = "wt"       // A Windows Terminal tab, under WSL
+	targetTerminal = "terminal" // The terminal emulator from the settings
+)
+
+// defaultTerminal is used for the terminal target when settings.json has no
+// "terminal" command; Debian and Ubuntu point it at the preferred emulator
+const defaultTerminal = "x-terminal-emulator -e"
+
+// holdOpen keeps the pane around after the command exits so its last output
+// can still be read
+const holdOpen = `status=$?; echo; printf '[exited with code %d, press enter to close] ' "$status"; read -r _`
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// terminalScript is the shell script a terminal target runs: the project's
+// own variables exported, since terminals don't always inherit the launcher's
+// environment, then the command in the project directory. Projects that run
+// on Windows get it through PowerShell, as they do in the background.
+func terminalScript(project Project, env []envVar) (string, error) {
+	var b strings.Builder
+	for _, e := range env {
+		if e.Source != "launcher" {
+			fmt.Fprintf(&b, "export %s=%s; ", e.Key, shellQuote(e.Value))
+		}
+	}
+	command := project.Command
+	if project.runsOnWindows() {
+		windowsPath, err := currentWSLPaths().toWindows(project.Path)
+		if err != nil {
+			return "", err
+		}
+		if shared := wslenv(env); shared != "" {
+			fmt.Fprintf(&b, "export WSLENV=%s; ", shellQuote(shared))
+		}
+		command = "powershell.exe -NoProfile -Command " + shellQuote("Set-Location -LiteralPath "+psQuote(windowsPath)+"; "+project.Command)
+	}
+	fmt.Fprintf(&b, "cd %s && %s; %s", shellQuote(project.Path), command, holdOpen)
+	return b.String(), nil
+}
+
+// tmuxName makes a project name usable as a tmux session or window name
+func tmuxName(name string) string {
+	return strings.NewReplacer(".", "_", ":", "_").Replace(name)
+}
+
+// terminalCommand builds the command that opens project in its terminal
+// target, and describes where it went
+func (m model) terminalCommand(project Project) (*exec.Cmd, string, error) {
+	env, err := launchEnv(project)
+	if err != nil {
+		return nil, "", err
+	}
+	script, err := terminalScript(project, env)
+	if err != nil {
+		return nil, "", err
+	}
+	name := tmuxName(project.Name)
+
+	switch project.Terminal {
+	case targetTmux:
+		if _, err := exec.LookPath("tmux"); err != nil {
+			return nil, "", errors.New("tmux is not installed")
+		}
+		if os.Getenv("TMUX") != "" {
+			return exec.Command("tmux", "new-window", "-n", name, "-c", project.Path, script), "tmux window " + name, nil
+		}
+		if exec.Command("tmux", "has-session", "-t", "="+name).Run() == nil {
+			return exec.Command("tmux", "new-window", "-t", "="+name+":", "-n", name, "-c", project.Path, script),
+				"tmux session " + name + " (tmux attach -t " + name + ")", nil
+		}
+		return exec.Command("tmux", "new-session", "-d", "-s", name, "-n", name, "-c", project.Path, script),
+			"tmux session " + name + " (tmux attach -t " + name + ")", nil
+
+	case targetWT:
+		if !runningInWSL() {
+			return nil, "", errors.New("Windows Terminal tabs need WSL")
+		}
+		args := []string{"-w", "0", "new-tab", "--title", project.Name}
+		if project.runsOnWindows() {
+			windowsPath, err := currentWSLPaths().toWindows(project.Path)
+			if err != nil {
+				return nil, "", err
+			}
+			args = append(args, "-d", windowsPath, "powershell.exe", "-NoExit", "-Command", project.Command)
+		} else {
+			args = append(args, "wsl.exe")
+			if distro := os.Getenv("WSL_DISTRO_NAME"); distro != "" {
+				args = append(args, "-d", distro)
+			}
+			args = append(args, "--", "bash", "-c", script)
+		}
+		// wt.exe splits its arguments into subcommands at every bare ;
+		for i, arg := range args {
+			args[i] = strings.ReplaceAll(arg, ";", `\;`)
+		}
+		return exec.Command("wt.exe", args...), "Windows Terminal tab", nil
+
+	case targetTerminal:
+		template := m.settings.Terminal
+		if template == "" {
+			template = defaultTerminal
+		}
+		placeholders := strings.NewReplacer("{dir}", project.Path, "{title}", project.Name)
+		var args []string
+		for _, field := range strings.Fields(template) {
+			args = append(args, placeholders.Replace(field))
+		}
+		args = append(args, "bash", "-c", script)
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = project.Path
+		cmd.Env = environ(env)
+		return cmd, "new " + args[0] + " window", nil
+	}
+	return nil, "", fmt.Errorf("unknown terminal target %q, use tmux, wt or terminal", project.Terminal)
+}
+
+// launchInTerminal opens project in its terminal target. The launcher
+// doesn't track it: its output and lifetime belong to that terminal.
+func (m model) launchInTerminal(project Project) tea.Cmd {
+	cmd, where, err := m.terminalCommand(project)
+	if err != nil {
+		return showStatus(fmt.Sprintf("❌ Failed to launch %s: %v", project.Name, err))
+	}
+	// tmux and wt.exe hand off and exit right away; terminal emulators keep
+	// running, so they are started without waiting
+	if project.Terminal == targetTerminal {
+		if err := cmd.Start(); err != nil {
+			return showStatus(fmt.Sprintf("❌ Failed to launch %s: %v", project.Name, err))
+		}
+		go cmd.Wait()
+		return showStatus(fmt.Sprintf("🪟 Launched %s in %s", project.Name, where))
+	}
+	return func() tea.Msg {
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return statusMsg{message: fmt.Sprintf("❌ Failed to launch %s: %v %s", project.Name, err, strings.TrimSpace(string(out)))}
+		}
+		return statusMsg{message: fmt.Sprintf("🪟 Launched %s in %s", project.Name, where)}
+	}
+}
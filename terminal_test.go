@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWTEscapesSemicolons(t *testing.T) {
+	t.Setenv("WSL_DISTRO_NAME", "Ubuntu")
+	bareSemicolon := regexp.MustCompile(`(^|[^\\]);`)
+
+	tests := []struct {
+		name    string
+		project Project
+	}{
+		{"linux", Project{Name: "API; dev", Path: "/home/u/api", Command: "make build; make run", Terminal: targetWT,
+			Env: map[string]string{"MODE": "a;b"}}},
+		{"windows", Project{Name: "App", Path: "/mnt/c/src/app", Command: "npm ci; npm start", Terminal: targetWT}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, _, err := model{}.terminalCommand(tt.project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, arg := range cmd.Args[1:] {
+				if bareSemicolon.MatchString(arg) {
+					t.Errorf("unescaped ; in wt.exe argument %q", arg)
+				}
+			}
+		})
+	}
+}
+
+func TestTerminalRunsWindowsProjectsInPowerShell(t *testing.T) {
+	t.Setenv("WSL_DISTRO_NAME", "Ubuntu")
+	t.Setenv("WSLENV", "")
+	windows := Project{Name: "App", Path: "/mnt/c/src/app", Command: "python main.py", Env: map[string]string{"PORT": "8080"}}
+	linux := Project{Name: "API", Path: "/home/u/api", Command: "python main.py"}
+
+	tests := []struct {
+		name     string
+		project  Project
+		target   string
+		want     []string
+		wantNone string
+	}{
+		{"terminal, windows drive", windows, targetTerminal, []string{
+			`export WSLENV='PORT'`,
+			`powershell.exe -NoProfile -Command 'Set-Location -LiteralPath '\''C:\src\app'\''; python main.py'`,
+		}, ""},
+		{"terminal, linux", linux, targetTerminal, []string{"cd '/home/u/api' && python main.py;"}, "powershell.exe"},
+		{"tmux, windows drive", windows, targetTmux, []string{"powershell.exe -NoProfile -Command"}, ""},
+		{"tmux, linux", linux, targetTmux, []string{"&& python main.py;"}, "powershell.exe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := exec.LookPath("tmux"); err != nil && tt.target == targetTmux {
+				t.Skip("tmux is not installed")
+			}
+			tt.project.Terminal = tt.target
+			m := model{settings: Settings{Terminal: "xterm -e"}}
+			cmd, _, err := m.terminalCommand(tt.project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			script := cmd.Args[len(cmd.Args)-1]
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("script %q does not contain %q", script, want)
+				}
+			}
+			if tt.wantNone != "" && strings.Contains(script, tt.wantNone) {
+				t.Errorf("script %q contains %q", script, tt.wantNone)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// projectEdit is the change to a single project made by one command. A nil
+// state means the project did not exist on that side.
+type projectEdit struct {
+	id          string
+	before      *Project
+	after       *Project
+	beforeIndex int // Position in the list before the command, to reinsert on undo
+	afterIndex  int // Position in the list after the command, to reinsert on redo
+}
+
+// undoEntry is one user-level command, e.g. a delete or a bulk import
+type undoEntry struct {
+	label string
+	edits []projectEdit
+}
+
+// undoStack holds the commands that can be undone and redone this session
+type undoStack struct {
+	undo []undoEntry
+	redo []undoEntry
+}
+
+// diffByID lists the projects that were added, removed or changed between two
+// versions of the project list
+func diffByID(before, after []Project) []projectEdit {
+	var edits []projectEdit
+	afterIndex := make(map[string]int, len(after))
+	for i, project := range after {
+		afterIndex[project.ID] = i
+	}
+	seen := make(map[string]bool, len(before))
+	for i := range before {
+		old := before[i]
+		seen[old.ID] = true
+		j, ok := afterIndex[old.ID]
+		switch {
+		case !ok:
+			edits = append(edits, projectEdit{id: old.ID, before: &old, beforeIndex: i})
+		case !reflect.DeepEqual(old, after[j]):
+			cur := after[j]
+			edits = append(edits, projectEdit{id: old.ID, before: &old, after: &cur, beforeIndex: i, afterIndex: j})
+		}
+	}
+	for j := range after {
+		if !seen[after[j].ID] {
+			cur := after[j]
+			edits = append(edits, projectEdit{id: cur.ID, after: &cur, afterIndex: j})
+		}
+	}
+	return edits
+}
+
+// recordChange pushes the difference between before and the current project
+// list as an undoable command. Nothing is recorded when nothing changed.
+func (m *model) recordChange(label string, before []Project) {
+	edits := diffByID(before, m.projects)
+	if len(edits) == 0 {
+		return
+	}
+	m.undo.undo = append(m.undo.undo, undoEntry{label: label, edits: edits})
+	m.undo.redo = nil
+}
+
+// snapshotProjects copies the project list so it can be passed to recordChange
+func (m *model) snapshotProjects() []Project {
+	before := make([]Project, len(m.projects))
+	copy(before, m.projects)
+	return before
+}
+
+// applyEdits moves every edited project to its before or after state.
+// Removals and replacements happen first, then reinsertions in ascending
+// position so each lands where it was.
+func (m *model) applyEdits(edits []projectEdit, toAfter bool) {
+	type insertion struct {
+		index   int
+		project Project
+	}
+	var inserts []insertion
+	for _, edit := range edits {
+		target, index := edit.before, edit.beforeIndex
+		if toAfter {
+			target, index = edit.after, edit.afterIndex
+		}
+		current := m.projectIndexByID(edit.id)
+		switch {
+		case target == nil && current != -1:
+			m.projects = append(m.projects[:current], m.projects[current+1:]...)
+		case target != nil && current == -1:
+			inserts = append(inserts, insertion{index, *target})
+		case target != nil:
+			m.projects[current] = *target
+		}
+	}
+
+	sort.Slice(inserts, func(i, j int) bool { return inserts[i].index < inserts[j].index })
+	for _, ins := range inserts {
+		index := min(ins.index, len(m.projects))
+		m.projects = append(m.projects[:index], append([]Project{ins.project}, m.projects[index:]...)...)
+	}
+}
+
+// undoLast reverts the most recent command
+func (m *model) undoLast() tea.Cmd {
+	if len(m.undo.undo) == 0 {
+		return showStatus("↩️ Nothing to undo")
+	}
+	entry := m.undo.undo[len(m.undo.undo)-1]
+
+	previous := m.snapshotProjects()
+	m.applyEdits(entry.edits, false)
+	if err := m.saveProjects(); err != nil {
+		m.projects = previous
+		return saveFailed(err)
+	}
+	m.undo.undo = m.undo.undo[:len(m.undo.undo)-1]
+	m.undo.redo = append(m.undo.redo, entry)
+	m.updateTable()
+	return showStatus(fmt.Sprintf("↩️ Undid %s", entry.label))
+}
+
+// redoLast reapplies the most recently undone command
+func (m *model) redoLast() tea.Cmd {
+	if len(m.undo.redo) == 0 {
+		return showStatus("↪️ Nothing to redo")
+	}
+	entry := m.undo.redo[len(m.undo.redo)-1]
+
+	previous := m.snapshotProjects()
+	m.applyEdits(entry.edits, true)
+	if err := m.saveProjects(); err != nil {
+		m.projects = previous
+		return saveFailed(err)
+	}
+	m.undo.redo = m.undo.redo[:len(m.undo.redo)-1]
+	m.undo.undo = append(m.undo.undo, entry)
+	m.updateTable()
+	return showStatus(fmt.Sprintf("↪️ Redid %s", entry.label))
+}
+
+// nextUndo and nextRedo describe what u and ctrl+r would do, for the footer
+func (s undoStack) nextUndo() string {
+	if len(s.undo) == 0 {
+		return ""
+	}
+	return s.undo[len(s.undo)-1].label
+}
+
+func (s undoStack) nextRedo() string {
+	if len(s.redo) == 0 {
+		return ""
+	}
+	return s.redo[len(s.redo)-1].label
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestUndoRedoRoundTrip(t *testing.T) {
+	start := []Project{
+		{ID: "a", Name: "A"}, {ID: "b", Name: "B"}, {ID: "c", Name: "C"}, {ID: "d", Name: "D"}, {ID: "e", Name: "E"},
+	}
+	tests := []struct {
+		name   string
+		change func(projects []Project) []Project
+	}{
+		{"add", func(p []Project) []Project { return append(p, Project{ID: "f", Name: "F"}) }},
+		{"duplicate in the middle", func(p []Project) []Project {
+			return slices.Insert(p, 2, Project{ID: "b2", Name: "B copy"})
+		}},
+		{"delete first", func(p []Project) []Project { return p[1:] }},
+		{"delete middle", func(p []Project) []Project { return slices.Delete(p, 2, 3) }},
+		{"delete last", func(p []Project) []Project { return p[:len(p)-1] }},
+		{"edit", func(p []Project) []Project { p[3].Command = "make run"; return p }},
+		{"bulk import", func(p []Project) []Project {
+			return append(p, Project{ID: "x", Name: "X"}, Project{ID: "y", Name: "Y"}, Project{ID: "z", Name: "Z"})
+		}},
+		{"restore", func(p []Project) []Project {
+			// A snapshot from before B and D existed, with C named differently
+			return []Project{p[0], {ID: "c", Name: "Old C"}, {ID: "old", Name: "Removed since"}, p[4]}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(slices.Clone(start))
+			m.store = newProjectStore(filepath.Join(t.TempDir(), "config.json"), 0)
+			if err := m.store.Save(m.projects); err != nil {
+				t.Fatal(err)
+			}
+
+			before := m.snapshotProjects()
+			m.projects = tt.change(m.snapshotProjects())
+			after := m.snapshotProjects()
+			m.recordChange(tt.name, before)
+
+			for i, step := range []struct {
+				apply func() // Undo or redo
+				want  []Project
+			}{
+				{func() { m.undoLast() }, start},
+				{func() { m.redoLast() }, after},
+				{func() { m.undoLast() }, start},
+			} {
+				step.apply()
+				if !reflect.DeepEqual(m.projects, step.want) {
+					t.Fatalf("step %d: projects = %v, want %v", i+1, names(m.projects), names(step.want))
+				}
+				saved, err := newProjectStore(m.store.path, 0).Load()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !slices.Equal(names(saved), names(step.want)) {
+					t.Errorf("step %d: saved %v, want %v", i+1, names(saved), names(step.want))
+				}
+			}
+		})
+	}
+}
+
+func names(projects []Project) []string {
+	list := make([]string, len(projects))
+	for i, project := range projects {
+		list[i] = project.Name
+	}
+	return list
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// WindowsLaunch describes how a Windows program is started, instead of
+// parsing it out of the command
+type WindowsLaunch struct {
+	Exe         string   `json:"exe,omitempty"`          // .exe, .com, .bat, .cmd, .ps1 or .lnk; the command's first word when empty
+	Args        []string `json:"args,omitempty"`         // Passed as they are, quoted for Windows
+	WindowStyle string   `json:"window_style,omitempty"` // normal, hidden, minimized or maximized
+	Elevated    bool     `json:"elevated,omitempty"`     // Run as administrator, after a UAC prompt
+}
+
+// windowsProgramTypes are started with Start-Process. Other commands, like
+// "python main.py", run directly in PowerShell.
+var windowsProgramTypes = []string{".exe", ".com", ".bat", ".cmd", ".ps1", ".lnk"}
+
+var windowStyles = map[string]string{
+	"normal":    "Normal",
+	"hidden":    "Hidden",
+	"minimized": "Minimized",
+	"maximized": "Maximized",
+}
+
+// psQuote quotes s as a PowerShell string literal, which expands nothing
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// windowsArg quotes one argument so CommandLineToArgvW, which most Windows
+// programs use to split their command line, gets it back unchanged
+func windowsArg(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(s, " \t\"") {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	slashes := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			slashes++
+		case '"':
+			// Backslashes before a quote are escapes, so double them and escape the quote
+			b.WriteString(strings.Repeat(`\`, slashes+1))
+			slashes = 0
+		default:
+			slashes = 0
+		}
+		b.WriteByte(s[i])
+	}
+	// Backslashes before the closing quote would escape it
+	b.WriteString(strings.Repeat(`\`, slashes))
+	b.WriteByte('"')
+	return b.String()
+}
+
+// cmdLine builds the command line cmd.exe runs for a batch file. cmd
+// expands %VAR% and treats &|<>^() as operators outside double quotes, and
+// it has no escape for % inside them. Plain words are escaped with ^, while
+// words that need quotes are passed in PL_ARG_<n> variables, set by the
+// returned PowerShell statements, and written as "%PL_ARG_<n>%": cmd expands
+// each variable once and doesn't parse its value again.
+func cmdLine(words []string) (line, setup string, err error) {
+	parts := make([]string, len(words))
+	for i, word := range words {
+		quoted := windowsArg(word)
+		switch {
+		case strings.Contains(word, `"`):
+			return "", "", fmt.Errorf("batch file argument %q contains a double quote, which cmd.exe cannot pass on safely", word)
+		case !strings.HasPrefix(quoted, `"`):
+			var b strings.Builder
+			for j := 0; j < len(word); j++ {
+				if strings.IndexByte("&|<>^()%", word[j]) >= 0 {
+					b.WriteByte('^')
+				}
+				b.WriteByte(word[j])
+			}
+			parts[i] = b.String()
+		case word == "":
+			parts[i] = quoted
+		default:
+			name := fmt.Sprintf("PL_ARG_%d", i)
+			setup += "$env:" + name + " = " + psQuote(word) + "; "
+			parts[i] = `"%` + name + `%"`
+		}
+	}
+	return strings.Join(parts, " "), setup, nil
+}
+
+// splitCommand splits a command line on spaces, keeping "double" or 'single'
+// quoted parts together. Backslashes are path separators, not escapes.
+func splitCommand(command string) []string {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote byte
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote, inWord = c, true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// windowsLaunch returns the structured launch for a project: the program
+// set as "exe" in its "windows" settings, or the command when its first word
+// is a Windows program. ok is false for commands that run directly in
+// PowerShell.
+func (p Project) windowsLaunch() (launch WindowsLaunch, ok bool) {
+	if p.Windows != nil {
+		launch = *p.Windows
+		if launch.Exe != "" {
+			return launch, true
+		}
+	}
+	words := splitCommand(p.Command)
+	if len(words) == 0 {
+		return launch, false
+	}
+	launch.Exe = words[0]
+	if len(launch.Args) == 0 {
+		launch.Args = words[1:]
+	}
+	ext := strings.ToLower(filepath.Ext(launch.Exe))
+	return launch, slices.Contains(windowsProgramTypes, ext)
+}
+
+// withCommand returns the project running command instead of its launch
+// command, for tasks and profiles. The "windows" settings describe the
+// launch command only, so they are dropped when the command changes.
+func (p Project) withCommand(command string) Project {
+	if command != p.Command {
+		p.Command = command
+		p.Windows = nil
+	}
+	return p
+}
+
+// startsDetached reports whether the project is started with Start-Process,
+// which returns as soon as the program is up. The launcher only tracks the
+// PowerShell wrapper, so its clean exit means the launch worked.
+func (p Project) startsDetached() bool {
+	if !p.runsOnWindows() {
+		return false
+	}
+	_, ok := p.windowsLaunch()
+	return ok
+}
+
+// powerShellScript builds the PowerShell command that launches a Windows
+// project from dir, a Windows path, and describes how it is started
+func (p Project) powerShellScript(dir string) (script, method string, err error) {
+	location := "Set-Location -LiteralPath " + psQuote(dir)
+	launch, ok := p.windowsLaunch()
+	if !ok {
+		// Start-Process options mean nothing to a command PowerShell runs itself
+		if w := p.Windows; w != nil && (len(w.Args) > 0 || w.WindowStyle != "" || w.Elevated) {
+			return "", "", fmt.Errorf(`"windows" settings need "exe" when the command doesn't start with a program (%s)`, strings.Join(windowsProgramTypes, ", "))
+		}
+		// Scripts like "python main.py" run directly
+		return location + "; " + p.Command, "PowerShell", nil
+	}
+
+	// Programs next to the project are passed by full path, as Start-Process
+	// only searches PATH for bare names
+	exe := launch.Exe
+	if !strings.ContainsAny(exe, `\/:`) {
+		if _, err := os.Stat(filepath.Join(p.Path, exe)); err == nil {
+			exe = strings.TrimSuffix(dir, `\`) + `\` + exe
+		}
+	}
+	args := make([]string, len(launch.Args))
+	for i, arg := range launch.Args {
+		args[i] = windowsArg(arg)
+	}
+
+	// Scripts need their interpreter; shortcuts and programs are opened by
+	// the shell, which resolves .lnk targets
+	filePath, argList := exe, strings.Join(args, " ")
+	method = "PowerShell Start-Process"
+	switch strings.ToLower(filepath.Ext(exe)) {
+	case ".bat", ".cmd":
+		// cmd strips the outer quotes of /c "..." and parses the rest itself
+		line, setup, err := cmdLine(append([]string{exe}, launch.Args...))
+		if err != nil {
+			return "", "", err
+		}
+		if setup != "" {
+			location += "; " + strings.TrimSuffix(setup, "; ")
+		}
+		filePath = "cmd.exe"
+		argList = `/c "` + line + `"`
+		method = "cmd.exe"
+	case ".ps1":
+		filePath = "powershell.exe"
+		argList = strings.TrimSpace("-NoProfile -ExecutionPolicy Bypass -File " + windowsArg(exe) + " " + argList)
+		method = "powershell.exe -File"
+	case ".lnk":
+		method = "shortcut"
+	}
+
+	var b strings.Builder
+	b.WriteString(location + "; Start-Process -FilePath " + psQuote(filePath))
+	if argList != "" {
+		b.WriteString(" -ArgumentList " + psQuote(argList))
+	}
+	b.WriteString(" -WorkingDirectory " + psQuote(dir))
+	if launch.WindowStyle != "" {
+		style, ok := windowStyles[strings.ToLower(launch.WindowStyle)]
+		if !ok {
+			return "", "", fmt.Errorf("unknown window_style %q, use normal, hidden, minimized or maximized", launch.WindowStyle)
+		}
+		b.WriteString(" -WindowStyle " + style)
+	}
+	if launch.Elevated {
+		b.WriteString(" -Verb RunAs")
+		method += ", elevated"
+	}
+	return b.String(), method, nil
+}
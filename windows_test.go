@@ -0,0 +1,195 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWindowsLaunchOnlyForOwnCommand(t *testing.T) {
+	project := Project{
+		Path:    t.TempDir(),
+		Command: "app.exe --serve",
+		Windows: &WindowsLaunch{Exe: "app.exe", Args: []string{"--serve"}},
+		Profiles: []Profile{
+			{Name: "dev", Command: "npm run dev"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		project Project
+		want    string
+	}{
+		{"launch command", project, "Start-Process -FilePath 'app.exe' -ArgumentList '--serve'"},
+		{"task", project.withCommand("npm run build"), "; npm run build"},
+		{"same command keeps settings", project.withCommand("app.exe --serve"), "Start-Process -FilePath 'app.exe'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, _, err := tt.project.powerShellScript(`C:\src`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(script, tt.want) {
+				t.Errorf("script %q does not contain %q", script, tt.want)
+			}
+		})
+	}
+
+	resolved, err := project.withProfile("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, _, _ := resolved.powerShellScript(`C:\src`)
+	if !strings.HasSuffix(script, "; npm run dev") {
+		t.Errorf("profile command not run as given: %q", script)
+	}
+}
+
+func TestCmdLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		words     []string
+		wantLine  string
+		wantSetup string
+		wantErr   bool
+	}{
+		{"plain", []string{"run.bat", "plain"}, "run.bat plain", "", false},
+		{"operators", []string{"run.bat", "a&b", "x|y>z<w", "(^)"}, "run.bat a^&b x^|y^>z^<w ^(^^^)", "", false},
+		{"percent", []string{"run.bat", "50%", "%PATH%"}, "run.bat 50^% ^%PATH^%", "", false},
+		{"empty", []string{"run.bat", ""}, `run.bat ""`, "", false},
+		{"quoted through variables", []string{`C:\My Tools\run.bat`, "a b&c %x%"},
+			`"%PL_ARG_0%" "%PL_ARG_1%"`, `$env:PL_ARG_0 = 'C:\My Tools\run.bat'; $env:PL_ARG_1 = 'a b&c %x%'; `, false},
+		{"double quote", []string{"run.bat", `x"&y`}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, setup, err := cmdLine(tt.words)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if line != tt.wantLine || setup != tt.wantSetup {
+				t.Errorf("cmdLine() = %q, %q, want %q, %q", line, setup, tt.wantLine, tt.wantSetup)
+			}
+		})
+	}
+}
+
+func TestBatchArgumentsCannotChainCommands(t *testing.T) {
+	project := Project{
+		Path:    t.TempDir(),
+		Windows: &WindowsLaunch{Exe: `C:\tools\run.bat`, Args: []string{"a&del x", "50%"}},
+	}
+	script, method, err := project.powerShellScript(`C:\src`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != "cmd.exe" {
+		t.Errorf("method = %q, want cmd.exe", method)
+	}
+	want := `Set-Location -LiteralPath 'C:\src'; $env:PL_ARG_1 = 'a&del x'; Start-Process -FilePath 'cmd.exe' -ArgumentList '/c "C:\tools\run.bat "%PL_ARG_1%" 50^%"'`
+	if !strings.Contains(script, want) {
+		t.Errorf("script %q does not contain %q", script, want)
+	}
+}
+
+func TestWindowsArg(t *testing.T) {
+	tests := []struct {
+		arg, want string
+	}{
+		{"", `""`},
+		{"plain", "plain"},
+		{`C:\dir\file`, `C:\dir\file`},
+		{"two words", `"two words"`},
+		{"tab\there", "\"tab\there\""},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\My Dir\`, `"C:\My Dir\\"`},
+		{`a\"b`, `"a\\\"b"`},
+	}
+	for _, tt := range tests {
+		if got := windowsArg(tt.arg); got != tt.want {
+			t.Errorf("windowsArg(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"app.exe", []string{"app.exe"}},
+		{"app.exe  --port 8080", []string{"app.exe", "--port", "8080"}},
+		{`"C:\Program Files\App\app.exe" -v`, []string{`C:\Program Files\App\app.exe`, "-v"}},
+		{`run.bat 'two words' ""`, []string{"run.bat", "two words", ""}},
+		{`say "it's"`, []string{"say", "it's"}},
+		{`pre"fix post"fix`, []string{"prefix postfix"}},
+		{`C:\tools\x.exe`, []string{`C:\tools\x.exe`}},
+	}
+	for _, tt := range tests {
+		if got := splitCommand(tt.command); !slices.Equal(got, tt.want) {
+			t.Errorf("splitCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestPowerShellScript(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "server.exe"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		project    Project
+		want       string
+		wantMethod string
+		wantErr    bool
+	}{
+		{"plain command", Project{Command: "python main.py"},
+			`Set-Location -LiteralPath 'C:\src'; python main.py`, "PowerShell", false},
+		{"program on PATH", Project{Command: "notepad.exe readme.txt"},
+			`Start-Process -FilePath 'notepad.exe' -ArgumentList 'readme.txt' -WorkingDirectory 'C:\src'`, "PowerShell Start-Process", false},
+		{"program in project", Project{Path: dir, Command: "server.exe"},
+			`Start-Process -FilePath 'C:\src\server.exe' -WorkingDirectory 'C:\src'`, "PowerShell Start-Process", false},
+		{"quoted arguments", Project{Windows: &WindowsLaunch{Exe: "app.exe", Args: []string{"two words", "it's"}}},
+			`-ArgumentList '"two words" it''s'`, "PowerShell Start-Process", false},
+		{"batch file", Project{Command: "build.cmd release"},
+			`-FilePath 'cmd.exe' -ArgumentList '/c "build.cmd release"'`, "cmd.exe", false},
+		{"powershell script", Project{Command: "setup.ps1 -Force"},
+			`-FilePath 'powershell.exe' -ArgumentList '-NoProfile -ExecutionPolicy Bypass -File setup.ps1 -Force'`, "powershell.exe -File", false},
+		{"shortcut", Project{Command: "App.lnk"},
+			`Start-Process -FilePath 'App.lnk' -WorkingDirectory`, "shortcut", false},
+		{"window style and elevation", Project{Windows: &WindowsLaunch{Exe: "tool.exe", WindowStyle: "Minimized", Elevated: true}},
+			`-WorkingDirectory 'C:\src' -WindowStyle Minimized -Verb RunAs`, "PowerShell Start-Process, elevated", false},
+		{"unknown window style", Project{Windows: &WindowsLaunch{Exe: "tool.exe", WindowStyle: "tiny"}},
+			"", "", true},
+		{"window style without a program", Project{Command: "npm test", Windows: &WindowsLaunch{WindowStyle: "hidden"}},
+			"", "", true},
+		{"elevation without a program", Project{Command: "python main.py", Windows: &WindowsLaunch{Elevated: true}},
+			"", "", true},
+		{"args without a program", Project{Command: "npm test", Windows: &WindowsLaunch{Args: []string{"--watch"}}},
+			"", "", true},
+		{"empty settings", Project{Command: "npm test", Windows: &WindowsLaunch{}},
+			`Set-Location -LiteralPath 'C:\src'; npm test`, "PowerShell", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, method, err := tt.project.powerShellScript(`C:\src`)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if !strings.Contains(script, tt.want) {
+				t.Errorf("script %q does not contain %q", script, tt.want)
+			}
+			if method != tt.wantMethod {
+				t.Errorf("method = %q, want %q", method, tt.wantMethod)
+			}
+		})
+	}
+}
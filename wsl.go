@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Project platforms: where the command runs when the launcher is in WSL
+const (
+	platformAuto    = "auto"    // Windows for projects on a Windows drive, Linux otherwise
+	platformWindows = "windows" // PowerShell, with the path translated for Windows
+	platformLinux   = "linux"   // bash, even on a Windows drive
+)
+
+const defaultMountRoot = "/mnt/"
+
+// wslPaths translates paths between WSL and Windows. Windows drives are
+// mounted under mountRoot (/mnt/ unless wsl.conf says otherwise) and the
+// distro's own files are reachable from Windows as \\wsl$\<distro>\...
+type wslPaths struct {
+	mountRoot string // Always ends in a slash
+	distro    string
+}
+
+var (
+	wslPathsOnce sync.Once
+	wslPathsVal  wslPaths
+)
+
+// currentWSLPaths reads the automount root once per run
+func currentWSLPaths() wslPaths {
+	wslPathsOnce.Do(func() {
+		wslPathsVal = wslPaths{
+			mountRoot: readMountRoot("/etc/wsl.conf"),
+			distro:    os.Getenv("WSL_DISTRO_NAME"),
+		}
+	})
+	return wslPathsVal
+}
+
+// readMountRoot finds root= in the [automount] section of wsl.conf
+func readMountRoot(confPath string) string {
+	file, err := os.Open(confPath)
+	if err != nil {
+		return defaultMountRoot
+	}
+	defer file.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || section != "automount" || strings.ToLower(strings.TrimSpace(key)) != "root" {
+			continue
+		}
+		root := strings.Trim(strings.TrimSpace(value), `"'`)
+		if root == "" || !strings.HasPrefix(root, "/") {
+			return defaultMountRoot
+		}
+		return strings.TrimSuffix(path.Clean(root), "/") + "/"
+	}
+	return defaultMountRoot
+}
+
+// runningInWSL reports whether the launcher runs inside Windows Subsystem
+// for Linux, where Windows programs can be started directly
+func runningInWSL() bool {
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	data, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	return err == nil && strings.Contains(strings.ToLower(string(data)), "microsoft")
+}
+
+// toWindowsPath converts a path on a mounted Windows drive, like
+// /mnt/d/src, to its Windows form D:\src
+func toWindowsPath(linuxPath string) (string, bool) {
+	return currentWSLPaths().driveToWindows(linuxPath)
+}
+
+// driveToWindows converts a path under the automount root to a drive path
+func (p wslPaths) driveToWindows(linuxPath string) (string, bool) {
+	rest, ok := strings.CutPrefix(path.Clean(linuxPath)+"/", p.mountRoot)
+	if !ok || len(rest) < 2 || !isDriveLetter(rest[0]) || rest[1] != '/' {
+		return "", false
+	}
+	drive, tail := strings.ToUpper(rest[:1])+":", strings.TrimSuffix(rest[1:], "/")
+	if tail == "" {
+		return drive + `\`, true
+	}
+	return drive + strings.ReplaceAll(tail, "/", `\`), true
+}
+
+// toWindows converts any absolute Linux path to a path Windows programs can
+// open: a drive path for mounted drives, \\wsl$\<distro>\... for the rest
+func (p wslPaths) toWindows(linuxPath string) (string, error) {
+	if windowsPath, ok := p.driveToWindows(linuxPath); ok {
+		return windowsPath, nil
+	}
+	if !path.IsAbs(linuxPath) {
+		return "", fmt.Errorf("%s is not an absolute path", linuxPath)
+	}
+	if p.distro == "" {
+		return "", fmt.Errorf("cannot reach %s from Windows: WSL_DISTRO_NAME is not set", linuxPath)
+	}
+	return `\\wsl$\` + p.distro + strings.ReplaceAll(path.Clean(linuxPath), "/", `\`), nil
+}
+
+// toLinux converts a Windows path back: drive paths (C:\src or C:/src) go
+// under the automount root and \\wsl$ or \\wsl.localhost paths of this
+// distro become plain Linux paths
+func (p wslPaths) toLinux(windowsPath string) (string, error) {
+	normalized := strings.ReplaceAll(windowsPath, `\`, "/")
+
+	if len(normalized) >= 2 && isDriveLetter(normalized[0]) && normalized[1] == ':' {
+		rest := strings.TrimPrefix(normalized[2:], "/")
+		return path.Clean(p.mountRoot + strings.ToLower(normalized[:1]) + "/" + rest), nil
+	}
+
+	for _, prefix := range []string{"//wsl$/", "//wsl.localhost/"} {
+		if len(normalized) < len(prefix) || !strings.EqualFold(normalized[:len(prefix)], prefix) {
+			continue
+		}
+		distro, rest, _ := strings.Cut(normalized[len(prefix):], "/")
+		if p.distro != "" && !strings.EqualFold(distro, p.distro) {
+			return "", fmt.Errorf("%s belongs to the %s distro, not %s", windowsPath, distro, p.distro)
+		}
+		return path.Clean("/" + rest), nil
+	}
+	return "", fmt.Errorf("%s is not a drive or \\\\wsl$ path", windowsPath)
+}
+
+func isDriveLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// runsOnWindows reports whether the project's command is started through
+// PowerShell rather than bash. Outside WSL a path like /mnt/c is just a
+// Linux directory, so "auto" only looks at the drive under WSL.
+func (p Project) runsOnWindows() bool {
+	switch strings.ToLower(p.Platform) {
+	case platformWindows:
+		return true
+	case platformLinux:
+		return false
+	}
+	if !runningInWSL() {
+		return false
+	}
+	_, onDrive := toWindowsPath(p.Path)
+	return onDrive
+}
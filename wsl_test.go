@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunsOnWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		inWSL   bool
+		project Project
+		want    bool
+	}{
+		{"drive under WSL", true, Project{Path: "/mnt/c/app"}, true},
+		{"home under WSL", true, Project{Path: "/home/u/app"}, false},
+		{"linux platform on a drive", true, Project{Path: "/mnt/c/app", Platform: "linux"}, false},
+		{"windows platform in home", true, Project{Path: "/home/u/app", Platform: "windows"}, true},
+		{"drive outside WSL", false, Project{Path: "/mnt/c/app"}, false},
+		{"auto outside WSL", false, Project{Path: "/mnt/d/src", Platform: "auto"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.inWSL {
+				t.Setenv("WSL_DISTRO_NAME", "Ubuntu")
+			} else {
+				t.Setenv("WSL_DISTRO_NAME", "")
+				if data, _ := os.ReadFile("/proc/sys/kernel/osrelease"); strings.Contains(strings.ToLower(string(data)), "microsoft") {
+					t.Skip("running under WSL")
+				}
+			}
+			if got := tt.project.runsOnWindows(); got != tt.want {
+				t.Errorf("runsOnWindows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWSLPathRoundTrip(t *testing.T) {
+	paths := wslPaths{mountRoot: "/mnt/", distro: "Ubuntu"}
+	custom := wslPaths{mountRoot: "/win/", distro: "Debian"}
+
+	tests := []struct {
+		name    string
+		paths   wslPaths
+		linux   string
+		windows string
+	}{
+		{"drive root", paths, "/mnt/c", `C:\`},
+		{"drive path", paths, "/mnt/d/src/app", `D:\src\app`},
+		{"spaces", paths, "/mnt/c/Program Files/App", `C:\Program Files\App`},
+		{"home", paths, "/home/u/app", `\\wsl$\Ubuntu\home\u\app`},
+		{"custom mount root", custom, "/win/e/work", `E:\work`},
+		{"default root under custom", custom, "/mnt/c/app", `\\wsl$\Debian\mnt\c\app`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows, err := tt.paths.toWindows(tt.linux)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if windows != tt.windows {
+				t.Errorf("toWindows(%q) = %q, want %q", tt.linux, windows, tt.windows)
+			}
+			linux, err := tt.paths.toLinux(windows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if linux != tt.linux {
+				t.Errorf("toLinux(%q) = %q, want %q", windows, linux, tt.linux)
+			}
+		})
+	}
+}
+
+func TestToLinux(t *testing.T) {
+	paths := wslPaths{mountRoot: "/mnt/", distro: "Ubuntu"}
+	tests := []struct {
+		windows string
+		want    string
+		wantErr bool
+	}{
+		{`C:/src/app`, "/mnt/c/src/app", false},
+		{`d:\src\`, "/mnt/d/src", false},
+		{`\\wsl.localhost\Ubuntu\home\u`, "/home/u", false},
+		{`\\WSL$\ubuntu\srv`, "/srv", false},
+		{`\\wsl$\Debian\home\u`, "", true},
+		{`\\server\share`, "", true},
+		{`relative\dir`, "", true},
+	}
+	for _, tt := range tests {
+		got, err := paths.toLinux(tt.windows)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("toLinux(%q) error = %v, want error %v", tt.windows, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("toLinux(%q) = %q, want %q", tt.windows, got, tt.want)
+		}
+	}
+}
+
+func TestToWindowsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths wslPaths
+		linux string
+	}{
+		{"relative path", wslPaths{mountRoot: "/mnt/", distro: "Ubuntu"}, "src/app"},
+		{"no distro", wslPaths{mountRoot: "/mnt/"}, "/home/u/app"},
+	}
+	for _, tt := range tests {
+		if got, err := tt.paths.toWindows(tt.linux); err == nil {
+			t.Errorf("%s: toWindows(%q) = %q, want an error", tt.name, tt.linux, got)
+		}
+	}
+}
+
+func TestReadMountRoot(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+		want string
+	}{
+		{"no automount section", "[network]\nhostname = box\n", "/mnt/"},
+		{"custom root", "[automount]\nroot = /win/\n", "/win/"},
+		{"no trailing slash", "[automount]\nroot=/drives\n", "/drives/"},
+		{"quoted", "[Automount]\nRoot = \"/c-drives/\"\n", "/c-drives/"},
+		{"other section", "[boot]\nroot = /boot/\n[automount]\nenabled = true\n", "/mnt/"},
+		{"comments", "# root = /x/\n[automount]\n; root = /y/\nroot = /z\n", "/z/"},
+		{"relative root", "[automount]\nroot = drives\n", "/mnt/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			confPath := filepath.Join(t.TempDir(), "wsl.conf")
+			if err := os.WriteFile(confPath, []byte(tt.conf), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if got := readMountRoot(confPath); got != tt.want {
+				t.Errorf("readMountRoot() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := readMountRoot(filepath.Join(t.TempDir(), "missing")); got != defaultMountRoot {
+		t.Errorf("readMountRoot() of a missing file = %q, want %q", got, defaultMountRoot)
+	}
+}